@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+/* The test timing a generated parser over the corpus, printing its measures on a line starting with PEG_BENCH. */
+const PEG_BENCH_TEMPLATE = `package {{.PackageName}}
+
+import (
+	"fmt"
+	"io/ioutil"
+	"runtime"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestPegBench(t *testing.T) {
+	files := []string{ {{range .Files}}{{printf "%q" .}},
+	{{end}} }
+	inputs := make([]string, len(files))
+	for i, file := range files {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		inputs[i] = string(b)
+	}
+
+	tokens, failures := 0, 0
+	for _, input := range inputs {
+		p := &{{.StructName}}{Buffer: input}
+		p.Init()
+		if {{if .HasValues}}_, {{end}}err := p.Parse(); err != nil {
+			failures++
+			continue
+		}
+		for range p.Tokens() {
+			tokens++
+		}
+	}
+
+	var durations []time.Duration
+	var memory runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&memory)
+	mallocs, allocated, bytes, start := memory.Mallocs, memory.TotalAlloc, 0, time.Now()
+	for pass := 0; pass == 0 || time.Since(start) < time.Second; pass++ {
+		for _, input := range inputs {
+			begin := time.Now()
+			p := &{{.StructName}}{Buffer: input}
+			p.Init()
+			p.Parse()
+			durations = append(durations, time.Since(begin))
+			bytes += len(input)
+		}
+	}
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&memory)
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	percentile := func(p int) int64 {
+		return int64(durations[(len(durations) - 1) * p / 100])
+	}
+	fmt.Printf("PEG_BENCH %v %v %v %v %v %v %v %v %v %v\n", len(durations), bytes, int64(elapsed),
+		memory.Mallocs - mallocs, memory.TotalAlloc - allocated, tokens, failures,
+		percentile(50), percentile(90), percentile(99))
+}
+`
+
+/* The option combinations compared by -bench. */
+var benchOptions = []struct {
+	name            string
+	inline, _switch bool
+}{
+	{"(none)", false, false},
+	{"-inline", true, false},
+	{"-switch", false, true},
+	{"-inline -switch", true, true},
+}
+
+/* The measures of a generated parser over the corpus. */
+type benchResult struct {
+	parses, bytes, elapsed, mallocs, allocated, tokens, failures int64
+	percentiles                                                  [3]time.Duration
+}
+
+/* The regular files of the corpus, a file or a directory walked recursively. */
+func benchCorpus(corpus string) (files []string, err error) {
+	err = filepath.Walk(corpus, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			if path, err = filepath.Abs(path); err != nil {
+				return err
+			}
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err == nil && len(files) == 0 {
+		err = fmt.Errorf("no files in %v", corpus)
+	}
+	return
+}
+
+/* Generates the parser of a freshly loaded tree with the options into the directory, along with the
+   Go files of the package next to the grammar and the test timing the parser, and runs the test. */
+func benchParser(directory, file string, files []string, tree *Tree) (*benchResult, error) {
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return nil, err
+	}
+	tree.prepare()
+	generated, _ := filepath.Abs(file + ".go")
+	sources, _ := filepath.Glob(filepath.Join(filepath.Dir(file), "*.go"))
+	for _, source := range sources {
+		if absolute, _ := filepath.Abs(source); absolute == generated || strings.HasSuffix(source, "_test.go") {
+			continue
+		}
+		code, err := ioutil.ReadFile(source)
+		if err != nil {
+			return nil, err
+		}
+		clause, err := parser.ParseFile(token.NewFileSet(), source, code, parser.PackageClauseOnly)
+		if err != nil || clause.Name.Name != tree.PackageName {
+			continue
+		}
+		if err := ioutil.WriteFile(filepath.Join(directory, filepath.Base(source)), code, 0644); err != nil {
+			return nil, err
+		}
+	}
+	tree.Compile(filepath.Join(directory, filepath.Base(file)+".go"))
+
+	test, err := os.Create(filepath.Join(directory, "peg_bench_test.go"))
+	if err != nil {
+		return nil, err
+	}
+	err = template.Must(template.New("bench").Parse(PEG_BENCH_TEMPLATE)).Execute(test, struct {
+		*Tree
+		Files []string
+	}{tree, files})
+	test.Close()
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(directory, "go.mod"), []byte("module pegbench\n"), 0644); err != nil {
+		return nil, err
+	}
+
+	command := exec.Command("go", "test", "-count=1", "-v", "-run", "^TestPegBench$")
+	command.Dir = directory
+	output, err := command.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("%v\n%s", err, output)
+	}
+	scanner := bufio.NewScanner(strings.NewReader(string(output)))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 11 || fields[0] != "PEG_BENCH" {
+			continue
+		}
+		var measures [10]int64
+		for i := range measures {
+			if measures[i], err = strconv.ParseInt(fields[i+1], 10, 64); err != nil {
+				return nil, err
+			}
+		}
+		r := &benchResult{parses: measures[0], bytes: measures[1], elapsed: measures[2], mallocs: measures[3],
+			allocated: measures[4], tokens: measures[5], failures: measures[6]}
+		for i := range r.percentiles {
+			r.percentiles[i] = time.Duration(measures[7+i])
+		}
+		return r, nil
+	}
+	return nil, fmt.Errorf("no measures in the output of the test:\n%s", output)
+}
+
+/* Bench generates the parser of the grammar with each combination of -inline and -switch, builds it
+   and times it over the files of the corpus, printing the measures of the combinations side by side.
+   The grammar is loaded again for each combination. */
+func Bench(out io.Writer, file, corpus string, load func() (*Tree, error)) error {
+	files, err := benchCorpus(corpus)
+	if err != nil {
+		return err
+	}
+	directory, err := ioutil.TempDir("", "peg-bench")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(directory)
+
+	fmt.Fprintf(out, "%-16v %10v %14v %14v %10v %12v %12v %12v %8v\n", "options", "MB/s", "allocs/parse",
+		"bytes/parse", "tokens", "p50", "p90", "p99", "failures")
+	for i, options := range benchOptions {
+		tree, err := load()
+		if err != nil {
+			return err
+		}
+		tree.inline, tree._switch = options.inline, options._switch
+		r, err := benchParser(filepath.Join(directory, strconv.Itoa(i)), file, files, tree)
+		if err != nil {
+			return err
+		}
+		throughput := float64(r.bytes) / 1e6 / time.Duration(r.elapsed).Seconds()
+		fmt.Fprintf(out, "%-16v %10.2f %14v %14v %10v %12v %12v %12v %8v\n", options.name, throughput,
+			r.mallocs/r.parses, r.allocated/r.parses, r.tokens, r.percentiles[0], r.percentiles[1],
+			r.percentiles[2], r.failures)
+	}
+	return nil
+}
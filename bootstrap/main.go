@@ -10,7 +10,7 @@ import (
 
 func main() {
 	runtime.GOMAXPROCS(2)
-	t := New(true, true)
+	t := New(Options{Inline: true, Switch: true})
 
 	/*package main
 	  type Peg Peg {
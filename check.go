@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+/* The command, version of peg and sha256 of the grammar read from the top of a generated parser. */
+type header struct {
+	command, version, hash string
+}
+
+/* The sha256 of a grammar recorded in the parsers generated from it. */
+func grammarHash(source string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(source)))
+}
+
+/* Reads the header written by Compile from the comments at the top of a generated parser. */
+func readHeader(file string) (*header, error) {
+	in, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+
+	h := &header{}
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "//") {
+			break
+		}
+		if strings.HasPrefix(line, "// Code generated by ") && strings.HasSuffix(line, "; DO NOT EDIT.") {
+			h.command = strings.TrimSuffix(strings.TrimPrefix(line, "// Code generated by "), "; DO NOT EDIT.")
+		} else if strings.HasPrefix(line, "// peg version ") {
+			fmt.Sscanf(line, "// peg version %s sha256 %s", &h.version, &h.hash)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if h.command == "" || h.hash == "" {
+		return nil, fmt.Errorf("%v doesn't record the grammar it was generated from", file)
+	}
+	return h, nil
+}
+
+/* Why the parser generated from the grammar isn't what peg would generate now, or nil when it is. With
+   a command, the parser must also have been generated by the same command. */
+func stale(file, command string) error {
+	source, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	h, err := readHeader(file + ".go")
+	if err != nil {
+		return err
+	}
+	switch {
+	case h.version != Version:
+		return fmt.Errorf("%v.go was generated by peg %v, not %v: run %v", file, h.version, Version, h.command)
+	case h.hash != grammarHash(string(source)):
+		return fmt.Errorf("%v.go is out of date with %v: run %v", file, file, h.command)
+	case command != "" && h.command != command:
+		return fmt.Errorf("%v.go was generated by %v, not %v", file, h.command, command)
+	}
+	return nil
+}
+
+/* Check prints the grammars whose generated parsers are missing, out of date or generated by another
+   version of peg, and why, returning an error when there are any. */
+func Check(files []string) error {
+	failed := 0
+	for _, file := range files {
+		if err := stale(file, ""); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%v of the %v parsers need to be generated again", failed, len(files))
+	}
+	return nil
+}
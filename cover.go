@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"html/template"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+/* The page printed by -coverreport -html, coloring the rules and alternatives by whether they matched. */
+const PEG_COVER_TEMPLATE = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Grammar}} coverage</title>
+<style>
+body { background: black; color: rgb(80, 80, 80); font-family: monospace; }
+#legend { color: rgb(160, 160, 160); margin-bottom: 1em; }
+.cov0 { color: rgb(192, 0, 0); }
+.cov1 { color: rgb(44, 212, 149); }
+</style>
+</head>
+<body>
+<div id="legend">{{.Grammar}}: rules {{.Rules}}, alternatives {{.Alternatives}}
+<span class="cov0">not matched</span> <span class="cov1">matched</span></div>
+<pre>{{.Source}}</pre>
+</body>
+</html>
+`
+
+/* A rule, or an alternative when rule is empty, read from the counts written by a parser generated with -cover. */
+type coverCount struct {
+	rule       string
+	begin, end int
+	count      int
+}
+
+/* Reads the counts written by the Write...Coverage function of a parser, adding up the counts of a rule or an alternative written more than once. */
+func readCoverage(profile string) (counts []*coverCount, err error) {
+	file, err := os.Open(profile)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	read, line := make(map[coverCount]*coverCount), 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || len(fields) > 2 && fields[0] == "peg" && fields[1] == "coverage" {
+			continue
+		}
+		c := coverCount{}
+		if len(fields) == 5 && fields[0] == "rule" {
+			c.rule, fields = fields[1], fields[2:]
+		} else if len(fields) == 4 && fields[0] == "alternative" {
+			fields = fields[1:]
+		} else {
+			return nil, fmt.Errorf("%v:%v: not a count written by a parser generated with -cover", profile, line)
+		}
+		var numbers [3]int
+		for i := range numbers {
+			if numbers[i], err = strconv.Atoi(fields[i]); err != nil {
+				return nil, fmt.Errorf("%v:%v: %v", profile, line, err)
+			}
+		}
+		c.begin, c.end = numbers[0], numbers[1]
+		if r, ok := read[c]; ok {
+			r.count += numbers[2]
+			continue
+		}
+		r := c
+		r.count = numbers[2]
+		read[c] = &r
+		counts = append(counts, &r)
+	}
+	return counts, scanner.Err()
+}
+
+/* CoverReport prints the counts written by a parser generated with -cover onto the grammar they were counted for:
+   each rule with the times it matched, followed by its alternatives that never matched, or with html a page of
+   the grammar colored by what matched. */
+func CoverReport(out io.Writer, file, profile string, html bool) error {
+	source, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	p := &Peg{Tree: New(Options{}), Buffer: string(source)}
+	p.Init()
+	if err := p.Parse(); err != nil {
+		return err
+	}
+	buffer := []rune(p.Buffer)
+	spacing := make([]bool, len(buffer)+1)
+	for token := range p.TokenTree.Tokens() {
+		if Rul3s[token.Rule] == "Spacing" {
+			for i := token.begin; i < token.end; i++ {
+				spacing[i] = true
+			}
+		}
+	}
+
+	counts, err := readCoverage(profile)
+	if err != nil {
+		return err
+	}
+	if len(counts) == 0 {
+		return fmt.Errorf("%v: no counts", profile)
+	}
+	rules, alternatives := [2]int{}, [2]int{}
+	for _, c := range counts {
+		if c.begin < 0 || c.end > len(buffer) || c.begin > c.end ||
+			c.rule != "" && string(buffer[c.begin:c.end]) != c.rule {
+			return fmt.Errorf("%v wasn't counted for %v as it is now", profile, file)
+		}
+		/* an alternative ends with the spacing after it */
+		for c.end > c.begin && spacing[c.end-1] {
+			c.end--
+		}
+		total := &alternatives
+		if c.rule != "" {
+			total = &rules
+		}
+		if total[1]++; c.count > 0 {
+			total[0]++
+		}
+	}
+	sort.SliceStable(counts, func(i, j int) bool {
+		return counts[i].begin < counts[j].begin || counts[i].begin == counts[j].begin && counts[i].end > counts[j].end
+	})
+
+	if html {
+		var page strings.Builder
+		stack, next := []*coverCount{}, 0
+		for i := 0; i <= len(buffer); i++ {
+			for len(stack) > 0 && stack[len(stack)-1].end <= i {
+				page.WriteString("</span>")
+				stack = stack[:len(stack)-1]
+			}
+			for ; next < len(counts) && counts[next].begin == i; next++ {
+				if c := counts[next]; c.end > c.begin {
+					class := "cov0"
+					if c.count > 0 {
+						class = "cov1"
+					}
+					fmt.Fprintf(&page, `<span class="%v" title="matched %v times">`, class, c.count)
+					stack = append(stack, c)
+				}
+			}
+			if i < len(buffer) {
+				page.WriteString(template.HTMLEscapeString(string(buffer[i])))
+			}
+		}
+		return template.Must(template.New("cover").Parse(PEG_COVER_TEMPLATE)).Execute(out, struct {
+			Grammar, Rules, Alternatives string
+			Source                       template.HTML
+		}{file, fmt.Sprintf("%v/%v", rules[0], rules[1]), fmt.Sprintf("%v/%v", alternatives[0], alternatives[1]),
+			template.HTML(page.String())})
+	}
+
+	line, symbol, position := 1, 1, 0
+	locate := func(c *coverCount) string {
+		for ; position < c.begin; position++ {
+			if buffer[position] == '\n' {
+				line, symbol = line+1, 1
+			} else {
+				symbol++
+			}
+		}
+		return fmt.Sprintf("%v:%v.%v:", file, line, symbol)
+	}
+	w := tabwriter.NewWriter(out, 0, 8, 1, ' ', 0)
+	for i, c := range counts {
+		if c.rule == "" {
+			if c.count == 0 {
+				text := strings.Join(strings.Fields(string(buffer[c.begin:c.end])), " ")
+				if runes := []rune(text); len(runes) > 60 {
+					text = string(runes[:57]) + "..."
+				}
+				fmt.Fprintf(w, "%v\t\t0\t%v\n", locate(c), text)
+			}
+			continue
+		}
+		matched, total := 0, 0
+		for _, a := range counts[i+1:] {
+			if a.rule != "" {
+				break
+			}
+			if total++; a.count > 0 {
+				matched++
+			}
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t", locate(c), c.rule, c.count)
+		if total > 0 {
+			fmt.Fprintf(w, "%v/%v alternatives", matched, total)
+		}
+		fmt.Fprintln(w)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	percent := 100.0 * float64(rules[0]+alternatives[0]) / float64(rules[1]+alternatives[1])
+	_, err = fmt.Fprintf(out, "total: rules %v/%v, alternatives %v/%v, %.1f%% matched\n", rules[0], rules[1],
+		alternatives[0], alternatives[1], percent)
+	return err
+}
@@ -0,0 +1,862 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+/* An expression of an exported grammar: actions, semantic predicates and cuts are removed,
+   literals are merged into strings and character alternates into classes. */
+type exported struct {
+	Type
+	text    string
+	ranges  [][2]rune
+	negated bool
+	items   []*exported
+}
+
+/* Converts the expression of a rule, returning nil when only the empty string is left. */
+func exportNode(n Node) *exported {
+	switch n.GetType() {
+	case TypeCharacter, TypeString:
+		return &exported{Type: TypeString, text: n.String()}
+	case TypeDot:
+		return &exported{Type: TypeDot}
+	case TypeName:
+		return &exported{Type: TypeName, text: n.String()}
+	case TypeBackReference:
+		return &exported{Type: TypeBackReference, text: n.String()}
+	case TypeRange:
+		if class, ok := exportClass(n); ok {
+			return class
+		}
+	case TypeAlternate, TypeUnorderedAlternate:
+		if class, ok := exportClass(n); ok {
+			return class
+		}
+		alternate := &exported{Type: TypeAlternate}
+		for _, element := range n.Slice() {
+			e := exportNode(element)
+			if e == nil && semantic(element) {
+				continue
+			} else if e == nil {
+				/* the empty string always matches, so the alternates after it are never tried */
+				if len(alternate.items) == 0 {
+					return nil
+				}
+				return &exported{Type: TypeQuery, items: []*exported{exportList(alternate)}}
+			}
+			if e.Type == TypeAlternate {
+				alternate.items = append(alternate.items, e.items...)
+			} else {
+				alternate.items = append(alternate.items, e)
+			}
+		}
+		return exportList(alternate)
+	case TypeSequence:
+		elements := n.Slice()
+		if len(elements) == 2 && elements[0].GetType() == TypePeekNot && elements[1].GetType() == TypeDot {
+			if class, _ := exportClass(elements[0].Front()); class != nil {
+				class.negated = true
+				return class
+			}
+			if except := exportNode(elements[0].Front()); except != nil {
+				return &exported{Type: TypeDot, items: []*exported{except}}
+			}
+		}
+		sequence := &exported{Type: TypeSequence}
+		for _, element := range elements {
+			e := exportNode(element)
+			switch {
+			case e == nil:
+				continue
+			case e.Type == TypeSequence:
+				sequence.items = append(sequence.items, e.items...)
+				continue
+			}
+			if last := len(sequence.items) - 1; last >= 0 && e.Type == TypeString && sequence.items[last].Type == TypeString {
+				sequence.items[last] = &exported{Type: TypeString, text: sequence.items[last].text + e.text}
+				continue
+			}
+			sequence.items = append(sequence.items, e)
+		}
+		return exportList(sequence)
+	case TypePeekFor, TypePeekNot, TypeQuery, TypeStar, TypePlus, TypeRepeat:
+		e := exportNode(n.Front())
+		if e == nil {
+			return nil
+		}
+		return &exported{Type: n.GetType(), text: n.String(), items: []*exported{e}}
+	case TypePush, TypeImplicitPush, TypeLabel:
+		return exportNode(n.Front())
+	case TypePrecedence:
+		/* the language of the operator levels, each operator followed by an operand */
+		elements := n.Slice()
+		operand, operators := exportNode(elements[0]), &exported{Type: TypeAlternate}
+		for _, level := range elements[1:] {
+			for _, operator := range level.Slice() {
+				if e := exportNode(operator); e != nil {
+					operators.items = append(operators.items, e)
+				}
+			}
+		}
+		if operand == nil || len(operators.items) == 0 {
+			return operand
+		}
+		repeated := &exported{Type: TypeSequence, items: []*exported{exportList(operators), operand}}
+		return &exported{Type: TypeSequence, items: []*exported{operand, {Type: TypeStar, items: []*exported{repeated}}}}
+	}
+	return nil
+}
+
+/* Whether the expression has a semantic predicate, so that it may fail without consuming anything. */
+func semantic(n Node) (found bool) {
+	walk(n, func(n Node) {
+		found = found || n.GetType() == TypePredicate
+	})
+	return
+}
+
+/* A sequence or alternate of a single expression is that expression. */
+func exportList(e *exported) *exported {
+	switch len(e.items) {
+	case 0:
+		return nil
+	case 1:
+		return e.items[0]
+	}
+	return e
+}
+
+/* Converts an alternate of characters and ranges into a class, which is preferred to the alternate when it has a range. */
+func exportClass(n Node) (*exported, bool) {
+	class, ranged := &exported{Type: TypeRange}, false
+	var collect func(n Node) bool
+	collect = func(n Node) bool {
+		switch n.GetType() {
+		case TypeCharacter:
+			c := []rune(n.String())[0]
+			class.ranges = append(class.ranges, [2]rune{c, c})
+		case TypeRange:
+			elements := n.Slice()
+			lower, upper := []rune(elements[0].String())[0], []rune(elements[1].String())[0]
+			class.ranges = append(class.ranges, [2]rune{lower, upper})
+			ranged = true
+		case TypeAlternate, TypeUnorderedAlternate:
+			for _, element := range n.Slice() {
+				if !collect(element) {
+					return false
+				}
+			}
+		default:
+			return false
+		}
+		return true
+	}
+	if !collect(n) {
+		return nil, false
+	}
+	return class, ranged || n.GetType() != TypeAlternate
+}
+
+/* How tightly a printed expression binds, parentheses are added where it isn't tight enough. */
+const (
+	bindAlternate = iota
+	bindSequence
+	bindAtom
+)
+
+/* Prints expressions in ISO 14977 EBNF or in the EBNF of the W3C XML recommendation,
+   inner printing the predicates within the comments of other predicates. */
+type ebnf struct {
+	w3c, inner bool
+}
+
+func (p ebnf) character(c rune) string {
+	if p.w3c {
+		return fmt.Sprintf("#x%X", c)
+	}
+	return fmt.Sprintf("? U+%04X ?", c)
+}
+
+/* Strings are quoted runs of printable characters, the others are printed by their code points. */
+func (p ebnf) string(text string) (string, int) {
+	var parts []string
+	run := &strings.Builder{}
+	quote := func() {
+		if run.Len() == 0 {
+			return
+		}
+		q := "\""
+		if strings.Contains(run.String(), q) {
+			q = "'"
+		}
+		parts = append(parts, q+run.String()+q)
+		run.Reset()
+	}
+	for _, c := range text {
+		switch {
+		case !unicode.IsPrint(c) || c == '"' && strings.Contains(run.String(), "'") ||
+			c == '\'' && strings.Contains(run.String(), "\""):
+			quote()
+			if !unicode.IsPrint(c) {
+				parts = append(parts, p.character(c))
+				continue
+			}
+			fallthrough
+		default:
+			run.WriteRune(c)
+		}
+	}
+	quote()
+	separator := " , "
+	if p.w3c {
+		separator = " "
+	}
+	if len(parts) > 1 {
+		return strings.Join(parts, separator), bindSequence
+	}
+	return parts[0], bindAtom
+}
+
+func (p ebnf) class(e *exported) string {
+	out := &strings.Builder{}
+	out.WriteString("[")
+	if e.negated {
+		out.WriteString("^")
+	}
+	character := func(c rune) {
+		if unicode.IsPrint(c) && !strings.ContainsRune("[]^-\\#?", c) && c != ' ' {
+			out.WriteRune(c)
+		} else {
+			fmt.Fprintf(out, "#x%X", c)
+		}
+	}
+	for _, r := range e.ranges {
+		character(r[0])
+		if r[1] != r[0] {
+			out.WriteString("-")
+			character(r[1])
+		}
+	}
+	out.WriteString("]")
+	return out.String()
+}
+
+func (p ebnf) comment(text string) string {
+	if p.w3c {
+		return "/* " + strings.Replace(text, "*/", "* /", -1) + " */"
+	}
+	return "(* " + strings.Replace(text, "*)", "* )", -1) + " *)"
+}
+
+/* Predicates are comments, written with the operators of peg inside other comments. */
+func (p ebnf) predicate(e *exported, operator, text string) (string, int, bool) {
+	inner := ebnf{w3c: p.w3c, inner: true}
+	if p.inner {
+		return operator + inner.group(e.items[0]), bindAtom, false
+	}
+	expression, _, _ := inner.print(e.items[0])
+	return p.comment(text + " " + expression), bindAtom, true
+}
+
+/* Prints an expression, returning how tightly it binds and whether it is only a comment. */
+func (p ebnf) print(e *exported) (text string, bind int, comment bool) {
+	group := func(e *exported, bind int) string {
+		text, b, _ := p.print(e)
+		if b < bind {
+			return "( " + text + " )"
+		}
+		return text
+	}
+	switch e.Type {
+	case TypeName:
+		return e.text, bindAtom, false
+	case TypeString:
+		text, bind = p.string(e.text)
+		return text, bind, false
+	case TypeRange:
+		if p.w3c {
+			return p.class(e), bindAtom, false
+		}
+		return "? " + p.class(e) + " ?", bindAtom, false
+	case TypeDot:
+		dot := "? any character ?"
+		if p.w3c {
+			dot = "[#x0-#x10FFFF]"
+		}
+		if len(e.items) == 0 {
+			return dot, bindAtom, false
+		}
+		return dot + " - " + group(e.items[0], bindAtom), bindSequence, false
+	case TypeBackReference:
+		if p.w3c && p.inner {
+			return "$" + e.text, bindAtom, false
+		} else if p.w3c {
+			return p.comment("the text matched by " + e.text), bindAtom, true
+		}
+		return "? the text matched by " + e.text + " ?", bindAtom, false
+	case TypePeekFor:
+		return p.predicate(e, "&", "followed by")
+	case TypePeekNot:
+		return p.predicate(e, "!", "not followed by")
+	case TypeAlternate:
+		alternates := make([]string, len(e.items))
+		for i, item := range e.items {
+			alternates[i] = group(item, bindSequence)
+		}
+		return strings.Join(alternates, " | "), bindAlternate, false
+	case TypeSequence:
+		out, previous, elements, comments := &strings.Builder{}, false, 0, 0
+		for i, item := range e.items {
+			text, bind, comment := p.print(item)
+			if bind < bindSequence {
+				text = "( " + text + " )"
+			}
+			switch {
+			case i == 0:
+			case !p.w3c && previous && !comment:
+				out.WriteString(" , ")
+			default:
+				out.WriteString(" ")
+			}
+			out.WriteString(text)
+			if comment {
+				comments++
+			} else {
+				elements++
+				previous = true
+			}
+		}
+		bind = bindAtom
+		if elements > 1 || elements == 1 && comments > 0 {
+			bind = bindSequence
+		}
+		return out.String(), bind, elements == 0
+	case TypeQuery:
+		if p.w3c {
+			return group(e.items[0], bindAtom) + "?", bindAtom, false
+		}
+		text, _, _ := p.print(e.items[0])
+		return "[ " + text + " ]", bindAtom, false
+	case TypeStar:
+		if p.w3c {
+			return group(e.items[0], bindAtom) + "*", bindAtom, false
+		}
+		text, _, _ := p.print(e.items[0])
+		return "{ " + text + " }", bindAtom, false
+	case TypePlus:
+		if p.w3c {
+			return group(e.items[0], bindAtom) + "+", bindAtom, false
+		}
+		text, _, _ := p.print(e.items[0])
+		return group(e.items[0], bindSequence) + " , { " + text + " }", bindSequence, false
+	case TypeRepeat:
+		return p.repeat(e)
+	}
+	return "", bindAtom, true
+}
+
+/* Bounded repetitions are written out, the W3C notation has no counts. */
+func (p ebnf) repeat(e *exported) (string, int, bool) {
+	min, max := repetitionBounds(&node{Type: TypeRepeat, string: e.text})
+	item := e.items[0]
+	var parts []string
+	if !p.w3c {
+		text, _, _ := p.print(item)
+		if min > 0 {
+			parts = append(parts, strconv.Itoa(min)+" * "+p.group(item))
+		}
+		switch {
+		case max < 0:
+			parts = append(parts, "{ "+text+" }")
+		case max > min:
+			parts = append(parts, strconv.Itoa(max-min)+" * [ "+text+" ]")
+		}
+		switch {
+		case len(parts) == 0:
+			return "", bindAtom, true
+		case len(parts) == 1 && min == 0 && max < 0:
+			return parts[0], bindAtom, false
+		}
+		return strings.Join(parts, " , "), bindSequence, false
+	}
+	atom := p.group(item)
+	for i := 0; i < min; i++ {
+		parts = append(parts, atom)
+	}
+	switch {
+	case max < 0:
+		parts = append(parts, atom+"*")
+	case max > min:
+		for i := min; i < max; i++ {
+			parts = append(parts, atom+"?")
+		}
+	}
+	if len(parts) == 0 {
+		return "", bindAtom, true
+	}
+	if len(parts) == 1 {
+		return parts[0], bindAtom, false
+	}
+	return strings.Join(parts, " "), bindSequence, false
+}
+
+func (p ebnf) group(e *exported) string {
+	text, bind, _ := p.print(e)
+	if bind < bindAtom {
+		return "( " + text + " )"
+	}
+	return text
+}
+
+/* A railroad diagram of width w, extending up above and down below the line entering and leaving it at y. */
+type railroad struct {
+	w, up, down int
+	draw        func(out io.Writer, x, y int)
+}
+
+const (
+	railCharacter = 8
+	railHeight    = 22
+	railGap       = 10
+)
+
+func railBox(text, class, link string) *railroad {
+	w := len([]rune(text))*railCharacter + 2*railGap
+	return &railroad{w: w, up: railHeight / 2, down: railHeight / 2, draw: func(out io.Writer, x, y int) {
+		if link != "" {
+			fmt.Fprintf(out, "<a href=\"#%v\">", html.EscapeString(link))
+		}
+		fmt.Fprintf(out, "<rect class=\"%v\" x=\"%v\" y=\"%v\" width=\"%v\" height=\"%v\"/>", class, x, y-railHeight/2, w, railHeight)
+		fmt.Fprintf(out, "<text class=\"%v\" x=\"%v\" y=\"%v\">%v</text>", class, x+w/2, y+4, html.EscapeString(text))
+		if link != "" {
+			fmt.Fprint(out, "</a>")
+		}
+		fmt.Fprintln(out)
+	}}
+}
+
+func railLine(out io.Writer, x0, y0, x1, y1 int) {
+	fmt.Fprintf(out, "<path d=\"M%v %vH%vV%vH%v\"/>\n", x0, y0, (x0+x1)/2, y1, x1)
+}
+
+func railSequence(items []*railroad) *railroad {
+	r := &railroad{}
+	for i, item := range items {
+		if i > 0 {
+			r.w += railGap
+		}
+		r.w += item.w
+		if item.up > r.up {
+			r.up = item.up
+		}
+		if item.down > r.down {
+			r.down = item.down
+		}
+	}
+	r.draw = func(out io.Writer, x, y int) {
+		for i, item := range items {
+			if i > 0 {
+				railLine(out, x-railGap, y, x, y)
+			}
+			item.draw(out, x, y)
+			x += item.w + railGap
+		}
+	}
+	return r
+}
+
+/* The first alternate is on the line, the others below it. */
+func railChoice(items []*railroad) *railroad {
+	r, w := &railroad{up: items[0].up, down: items[0].down}, 0
+	for i, item := range items {
+		if item.w > w {
+			w = item.w
+		}
+		if i > 0 {
+			r.down += railGap + item.up + item.down
+		}
+	}
+	r.w = w + 4*railGap
+	r.draw = func(out io.Writer, x, y int) {
+		base := y
+		for i, item := range items {
+			if i > 0 {
+				base += items[i-1].down + railGap + item.up
+			}
+			railLine(out, x, y, x+2*railGap, base)
+			item.draw(out, x+2*railGap, base)
+			fmt.Fprintf(out, "<path d=\"M%v %vH%vV%vH%v\"/>\n", x+2*railGap+item.w, base, x+r.w-railGap, y, x+r.w)
+		}
+	}
+	return r
+}
+
+/* The item is repeated by the line returning below it, which is labeled with the number of repetitions. */
+func railLoop(item *railroad, label string) *railroad {
+	r := &railroad{w: item.w + 4*railGap, up: item.up, down: item.down + 2*railGap}
+	r.draw = func(out io.Writer, x, y int) {
+		railLine(out, x, y, x+2*railGap, y)
+		item.draw(out, x+2*railGap, y)
+		railLine(out, x+2*railGap+item.w, y, x+r.w, y)
+		back := y + item.down + railGap
+		fmt.Fprintf(out, "<path d=\"M%v %vV%vH%vV%v\"/>\n", x+3*railGap+item.w, y, back, x+railGap, y)
+		if label != "" {
+			fmt.Fprintf(out, "<text class=\"label\" x=\"%v\" y=\"%v\">%v</text>\n", x+r.w/2, back+railGap, html.EscapeString(label))
+		}
+	}
+	return r
+}
+
+func railEmpty() *railroad {
+	return &railroad{draw: func(out io.Writer, x, y int) {}}
+}
+
+func railDiagram(e *exported) *railroad {
+	if e == nil {
+		return railEmpty()
+	}
+	w3c := ebnf{w3c: true}
+	switch e.Type {
+	case TypeName:
+		return railBox(e.text, "nonterminal", e.text)
+	case TypeString:
+		text, _ := w3c.string(e.text)
+		return railBox(text, "terminal", "")
+	case TypeRange:
+		return railBox(w3c.class(e), "terminal", "")
+	case TypeDot:
+		if len(e.items) > 0 {
+			text, _, _ := w3c.print(e.items[0])
+			return railBox("any character except "+text, "special", "")
+		}
+		return railBox("any character", "special", "")
+	case TypeBackReference:
+		return railBox("$"+e.text, "special", "")
+	case TypePeekFor, TypePeekNot:
+		text, _, _ := w3c.print(e.items[0])
+		prefix := "&"
+		if e.Type == TypePeekNot {
+			prefix = "!"
+		}
+		return railBox(prefix+text, "predicate", "")
+	case TypeAlternate, TypeSequence:
+		items := make([]*railroad, len(e.items))
+		for i, item := range e.items {
+			items[i] = railDiagram(item)
+		}
+		if e.Type == TypeAlternate {
+			return railChoice(items)
+		}
+		return railSequence(items)
+	case TypeQuery:
+		return railChoice([]*railroad{railDiagram(e.items[0]), railEmpty()})
+	case TypeStar:
+		return railChoice([]*railroad{railLoop(railDiagram(e.items[0]), ""), railEmpty()})
+	case TypePlus:
+		return railLoop(railDiagram(e.items[0]), "")
+	case TypeRepeat:
+		loop := railLoop(railDiagram(e.items[0]), "{"+e.text+"}")
+		if min, _ := repetitionBounds(&node{Type: TypeRepeat, string: e.text}); min == 0 {
+			return railChoice([]*railroad{loop, railEmpty()})
+		}
+		return loop
+	}
+	return railEmpty()
+}
+
+const railStyle = `svg { display: block; margin-bottom: 2em; }
+path { fill: none; stroke: #333; stroke-width: 1.5; }
+rect { stroke: #333; stroke-width: 1.5; }
+rect.terminal { fill: #ffd; rx: 10; }
+rect.nonterminal { fill: #dfd; }
+rect.special { fill: #eee; rx: 10; }
+rect.predicate { fill: #fff; stroke-dasharray: 4 2; }
+text { font: 13px monospace; text-anchor: middle; }
+text.label { font-size: 11px; }
+a text { fill: #006; }`
+
+/* How tightly an expression of a native grammar binds. */
+const (
+	pegAlternate = iota
+	pegSequence
+	pegLabeled
+	pegPrefix
+	pegSuffix
+	pegPrimary
+)
+
+func pegCharacter(c rune, class bool) string {
+	switch c {
+	case '\a':
+		return "\\a"
+	case '\b':
+		return "\\b"
+	case '\x1B':
+		return "\\e"
+	case '\f':
+		return "\\f"
+	case '\n':
+		return "\\n"
+	case '\r':
+		return "\\r"
+	case '\t':
+		return "\\t"
+	case '\v':
+		return "\\v"
+	case '\\':
+		return "\\\\"
+	case '\'':
+		if !class {
+			return "\\'"
+		}
+	case '[', ']', '-':
+		if class {
+			return "\\" + string(c)
+		}
+	}
+	if c < ' ' || c == 0x7F {
+		return fmt.Sprintf("\\%03o", c)
+	}
+	return string(c)
+}
+
+func pegClass(class *exported) string {
+	out := &strings.Builder{}
+	out.WriteString("[")
+	if class.negated {
+		out.WriteString("^")
+	} else if class.ranges[0][0] == '^' {
+		/* ^ can't be escaped, so it isn't written first */
+		class.ranges = append(class.ranges[1:], class.ranges[0])
+	}
+	for _, r := range class.ranges {
+		out.WriteString(pegCharacter(r[0], true))
+		if r[1] != r[0] {
+			out.WriteString("-")
+			out.WriteString(pegCharacter(r[1], true))
+		}
+	}
+	out.WriteString("]")
+	return out.String()
+}
+
+/* Prints an expression in the syntax of peg.peg, returning how tightly it binds. */
+func pegExpression(n Node) (string, int) {
+	group := func(n Node, bind int) string {
+		text, b := pegExpression(n)
+		if b < bind {
+			return "(" + text + ")"
+		}
+		return text
+	}
+	switch n.GetType() {
+	case TypeName:
+		return n.String(), pegPrimary
+	case TypeDot:
+		return ".", pegPrimary
+	case TypeCharacter, TypeString:
+		text := ""
+		for _, c := range n.String() {
+			text += pegCharacter(c, false)
+		}
+		return "'" + text + "'", pegPrimary
+	case TypeRange:
+		class, _ := exportClass(n)
+		return pegClass(class), pegPrimary
+	case TypeAlternate, TypeUnorderedAlternate:
+		if class, ok := exportClass(n); ok {
+			return pegClass(class), pegPrimary
+		}
+		elements := n.Slice()
+		alternates := make([]string, len(elements))
+		for i, element := range elements {
+			if text, _ := pegExpression(element); text == "" && i < len(elements)-1 {
+				alternates[i] = "()"
+				continue
+			}
+			alternates[i] = group(element, pegSequence)
+		}
+		return strings.Join(alternates, " / "), pegAlternate
+	case TypeSequence:
+		elements := n.Slice()
+		if len(elements) == 2 && elements[0].GetType() == TypePeekNot && elements[1].GetType() == TypeDot {
+			if class, _ := exportClass(elements[0].Front()); class != nil {
+				class.negated = true
+				return pegClass(class), pegPrimary
+			}
+		}
+		var items []*node
+		characters := ""
+		for _, element := range elements {
+			if element.GetType() == TypeCharacter {
+				characters += element.String()
+				continue
+			}
+			if characters != "" {
+				items, characters = append(items, &node{Type: TypeString, string: characters}), ""
+			}
+			if text, _ := pegExpression(element); text != "" {
+				items = append(items, element)
+			}
+		}
+		if characters != "" {
+			items = append(items, &node{Type: TypeString, string: characters})
+		}
+		if len(items) == 1 {
+			return pegExpression(items[0])
+		}
+		texts := make([]string, len(items))
+		for i, item := range items {
+			texts[i] = group(item, pegLabeled)
+		}
+		return strings.Join(texts, " "), pegSequence
+	case TypePeekFor:
+		return "&" + group(n.Front(), pegSuffix), pegPrefix
+	case TypePeekNot:
+		return "!" + group(n.Front(), pegSuffix), pegPrefix
+	case TypePredicate:
+		return "&{" + n.String() + "}", pegPrefix
+	case TypeAction:
+		return "{" + n.String() + "}", pegPrimary
+	case TypeCommit:
+		return "^", pegPrimary
+	case TypeBackReference:
+		return "$" + n.String(), pegPrimary
+	case TypeQuery:
+		return group(n.Front(), pegPrimary) + "?", pegSuffix
+	case TypeStar:
+		return group(n.Front(), pegPrimary) + "*", pegSuffix
+	case TypePlus:
+		return group(n.Front(), pegPrimary) + "+", pegSuffix
+	case TypeRepeat:
+		return group(n.Front(), pegPrimary) + "{" + n.String() + "}", pegSuffix
+	case TypePush, TypeImplicitPush:
+		text, _ := pegExpression(n.Front())
+		return "<" + text + ">", pegPrimary
+	case TypeLabel:
+		return n.String() + ":" + group(n.Front(), pegPrefix), pegLabeled
+	case TypePrecedence:
+		elements := n.Slice()
+		text, _ := pegExpression(elements[0])
+		for _, level := range elements[1:] {
+			operators := make([]string, 0, level.Len())
+			for _, operator := range level.Slice() {
+				operators = append(operators, group(operator, pegSequence))
+			}
+			text += " %" + level.String() + " " + strings.Join(operators, " / ")
+		}
+		return text, pegAlternate
+	}
+	return "", pegPrimary
+}
+
+/* Export prints the rules of the parsed grammar as ebnf (ISO 14977), w3c-ebnf or as a railroad
+   HTML page of SVG diagrams. Actions are left out and predicates become comments.
+   It also prints the whole grammar in the syntax of peg.peg, for grammars that were imported. */
+func (t *Tree) Export(out io.Writer, notation string) error {
+	var rules []*node
+	name := ""
+	for _, n := range t.Slice() {
+		switch n.GetType() {
+		case TypeRule:
+			rules = append(rules, n)
+		case TypePeg:
+			name = n.String()
+		}
+	}
+
+	switch notation {
+	case "ebnf", "w3c-ebnf":
+		p, width := ebnf{w3c: notation == "w3c-ebnf"}, 0
+		for _, rule := range rules {
+			if length := len(rule.String()); length > width {
+				width = length
+			}
+		}
+		for _, rule := range rules {
+			text := ""
+			if e := exportNode(rule.Front()); e != nil {
+				text, _, _ = p.print(e)
+			}
+			if p.w3c {
+				if text == "" {
+					text = p.comment("empty")
+				}
+				fmt.Fprintf(out, "%-*v ::= %v\n", width, rule, text)
+			} else {
+				fmt.Fprintf(out, "%-*v = %v ;\n", width, rule, text)
+			}
+		}
+	case "peg":
+		width := 0
+		for _, rule := range rules {
+			length := len(rule.String())
+			if t.suppressed[rule.String()] {
+				length++
+			}
+			if length > width {
+				width = length
+			}
+		}
+		for _, n := range t.Slice() {
+			switch n.GetType() {
+			case TypePackage:
+				fmt.Fprintf(out, "package %v\n\n", n)
+			case TypePeg:
+				fmt.Fprintf(out, "type %v Peg {%v}\n\n", n, n.Front())
+			case TypeDirective:
+				fmt.Fprintf(out, "%%%v", n)
+				for _, argument := range n.Slice() {
+					fmt.Fprintf(out, " %v", argument)
+				}
+				fmt.Fprintln(out)
+			case TypeRule:
+				name := n.String()
+				if t.suppressed[name] {
+					name = "~" + name
+				}
+				fmt.Fprintf(out, "%-*v <- ", width, name)
+				expression := n.Front()
+				if text, bind := pegExpression(expression); bind == pegAlternate && expression.GetType() != TypePrecedence {
+					for i, alternate := range expression.Slice() {
+						if i > 0 {
+							fmt.Fprintf(out, "\n%v/ ", strings.Repeat(" ", width+1))
+						}
+						text, _ := pegExpression(alternate)
+						fmt.Fprint(out, text)
+					}
+				} else {
+					fmt.Fprint(out, text)
+				}
+				if value := expression.Next(); value != nil && value.GetType() == TypeValue {
+					fmt.Fprintf(out, "\n%v: %v {%v}", strings.Repeat(" ", width+4), value, value.Front())
+				}
+				fmt.Fprintln(out)
+			}
+		}
+	case "railroad":
+		fmt.Fprintf(out, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%v</title>\n", html.EscapeString(name))
+		fmt.Fprintf(out, "<style>\n%v\n</style>\n</head>\n<body>\n", railStyle)
+		for _, rule := range rules {
+			diagram := railDiagram(exportNode(rule.Front()))
+			width, height := diagram.w+4*railGap, diagram.up+diagram.down+2*railGap
+			y := diagram.up + railGap
+			fmt.Fprintf(out, "<h2 id=\"%v\">%v</h2>\n", html.EscapeString(rule.String()), html.EscapeString(rule.String()))
+			fmt.Fprintf(out, "<svg width=\"%v\" height=\"%v\">\n", width, height)
+			fmt.Fprintf(out, "<path d=\"M0 %vv%vM0 %vH%vM%v %vH%vv%vM%v %vv%v\"/>\n",
+				y-railGap/2, railGap, y, 2*railGap, 2*railGap+diagram.w, y, width, -railGap/2, width, y, railGap/2)
+			diagram.draw(out, 2*railGap, y)
+			fmt.Fprintln(out, "</svg>")
+		}
+		fmt.Fprintln(out, "</body>\n</html>")
+	default:
+		return fmt.Errorf("unknown export notation %q, expected ebnf, w3c-ebnf, railroad or peg", notation)
+	}
+	return nil
+}
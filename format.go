@@ -0,0 +1,301 @@
+package main
+
+import (
+	"strings"
+)
+
+/* The rules of peg.peg matching the lexemes of a grammar, looked up by name so that
+   the formatter also builds against the bootstrap grammar. */
+var lexemes = map[string]bool{
+	"Identifier":    true,
+	"Label":         true,
+	"BackReference": true,
+	"ValueType":     true,
+	"Literal":       true,
+	"Class":         true,
+	"LeftArrow":     true,
+	"Slash":         true,
+	"And":           true,
+	"Not":           true,
+	"Question":      true,
+	"Star":          true,
+	"Plus":          true,
+	"Repetition":    true,
+	"Open":          true,
+	"Close":         true,
+	"Dot":           true,
+	"Colon":         true,
+	"Associativity": true,
+	"Commit":        true,
+	"Action":        true,
+	"Begin":         true,
+	"End":           true,
+}
+
+/* A lexeme, a comment or a blank line of a grammar being formatted, with the column it was written in. */
+type element struct {
+	text, rule       string
+	column           int
+	comment, blank   bool
+	first, spaced    bool
+	trailing         bool
+	definition, item bool
+}
+
+/* Splits the parsed grammar into its header, ending with the parser declaration, and the elements following it. */
+func (p *Peg) elements() (header string, elements []element) {
+	buffer := []rune(p.Buffer)
+	spacing, comments := make([]bool, len(buffer)+1), make(map[int]int)
+	rules, items, state := make(map[int]string), make(map[int]bool), -1
+	for token := range p.TokenTree.Tokens() {
+		begin, end, rule := int(token.begin), int(token.end), Rul3s[token.Rule]
+		switch {
+		case rule == "Spacing":
+			for i := begin; i < end; i++ {
+				spacing[i] = true
+			}
+		case rule == "Comment":
+			comments[begin] = end
+		case rule == "Definition" || rule == "Directive":
+			items[begin] = true
+		case lexemes[rule]:
+			if _, ok := rules[begin]; !ok {
+				rules[begin] = rule
+			}
+			if rule == "Action" && state == -1 {
+				state = end
+			}
+		}
+	}
+	for state > 0 && spacing[state-1] {
+		state--
+	}
+	header = string(buffer[:state])
+
+	/* the columns of the runes, with tabs every 8 columns */
+	columns := make([]int, len(buffer)+1)
+	for i, column := 0, 0; i < len(buffer); i++ {
+		columns[i] = column
+		switch buffer[i] {
+		case '\n':
+			column = 0
+		case '\t':
+			column += 8 - column%8
+		default:
+			column++
+		}
+	}
+
+	newlines, line, spaced := 0, true, false
+	for i := state; i < len(buffer); {
+		if end, ok := comments[i]; ok {
+			if newlines > 1 || newlines == 1 && !line {
+				elements = append(elements, element{blank: true})
+			}
+			text := strings.TrimRight(string(buffer[i:end]), "\r\n")
+			elements = append(elements, element{text: text, column: columns[i], comment: true, trailing: newlines == 0 && line})
+			newlines, line, spaced, i = 0, false, true, end
+			continue
+		} else if spacing[i] {
+			if buffer[i] == '\n' || buffer[i] == '\r' && (i+1 == len(buffer) || buffer[i+1] != '\n') {
+				newlines++
+			}
+			spaced = true
+			i++
+			continue
+		}
+
+		if newlines > 1 || newlines == 1 && !line {
+			elements = append(elements, element{blank: true})
+		}
+		end := i + 1
+		for end < len(buffer) && !spacing[end] {
+			if _, ok := rules[end]; ok && buffer[end-1] != '%' && buffer[end-1] != '~' {
+				break
+			}
+			end++
+		}
+		e := element{text: string(buffer[i:end]), rule: rules[i], column: columns[i], first: newlines > 0 || !line,
+			spaced: spaced, item: items[i]}
+		if e.rule == "" {
+			e.rule = rules[i+1]
+		}
+		e.definition = e.item && buffer[i] != '%'
+		elements = append(elements, e)
+		newlines, line, spaced, i = 0, true, false, end
+	}
+	return
+}
+
+/* Format prints the grammar with the arrows and the top level slashes of each block of rules aligned,
+   keeping its header, comments, actions, blank lines and line breaks as they were written, and the actions
+   and trailing comments in their columns when the code before them allows. */
+func (p *Peg) Format() string {
+	header, elements := p.elements()
+
+	/* the width of the rule names in each block of definitions not separated by a blank line */
+	widths, start, width := make([]int, len(elements)), 0, 0
+	next := func(i int) *element {
+		for i++; i < len(elements); i++ {
+			if !elements[i].comment && !elements[i].blank {
+				return &elements[i]
+			}
+		}
+		return nil
+	}
+	for i, e := range elements {
+		if e.blank {
+			if n := next(i); n == nil || n.item {
+				for j := start; j < i; j++ {
+					widths[j] = width
+				}
+				start, width = i, 0
+			}
+		} else if e.definition {
+			if length := len([]rune(e.text)); length > width {
+				width = length
+			}
+		}
+	}
+	for j := start; j < len(elements); j++ {
+		widths[j] = width
+	}
+
+	type output struct {
+		code, comment string
+		column        int
+	}
+	lines, line := []output{}, &strings.Builder{}
+	flush := func() {
+		if line.Len() > 0 {
+			lines = append(lines, output{code: strings.TrimRight(line.String(), " ")})
+			line.Reset()
+		}
+	}
+	indent := func(n int) {
+		line.WriteString(strings.Repeat(" ", n))
+	}
+
+	inside, directive, precedence, fresh, previous, depth := false, false, false, false, "", 0
+	for i, e := range elements {
+		width := widths[i]
+		switch {
+		case e.blank:
+			flush()
+			if length := len(lines); length == 0 || lines[length-1] != (output{}) {
+				lines = append(lines, output{})
+			}
+			continue
+		case e.comment && e.trailing:
+			flush()
+			if len(lines) == 0 {
+				header += padding(header[strings.LastIndex(header, "\n")+1:], e.column) + e.text
+			} else {
+				lines[len(lines)-1].comment, lines[len(lines)-1].column = e.text, e.column
+			}
+			continue
+		case e.comment:
+			flush()
+			if n := next(i); inside && !directive && n != nil && !n.item {
+				indent(width + 4 + 2*depth)
+			}
+			line.WriteString(e.text)
+			flush()
+			continue
+		case e.item:
+			flush()
+			inside, directive, precedence, depth = true, !e.definition, false, 0
+			line.WriteString(e.text)
+			if e.definition {
+				indent(width - len([]rune(e.text)))
+			}
+			fresh, previous = false, e.rule
+			continue
+		}
+
+		switch {
+		case directive:
+			if line.Len() == 0 {
+				indent(4)
+				fresh = true
+			}
+		case e.rule == "LeftArrow" && depth == 0:
+			line.WriteString(" <- ")
+			fresh, previous = true, e.rule
+			continue
+		case e.rule == "Slash" && depth == 0 && !precedence:
+			flush()
+			indent(width + 1)
+			line.WriteString("/ ")
+			fresh, previous = true, e.rule
+			continue
+		case (e.rule == "Associativity" || e.rule == "Colon") && depth == 0:
+			flush()
+			indent(width + 4)
+			line.WriteString(e.text)
+			precedence, fresh, previous = e.rule == "Associativity", false, e.rule
+			continue
+		case e.first && !fresh || line.Len() == 0:
+			flush()
+			level := depth
+			if e.rule == "Close" || e.rule == "End" {
+				level--
+			}
+			if precedence {
+				level += 2
+			}
+			indent(width + 4 + 2*level)
+			fresh = true
+		}
+
+		switch {
+		case fresh && e.rule == "Action" && previous == "Slash":
+			if length := len([]rune(line.String())); length < e.column {
+				indent(e.column - length)
+			}
+		case fresh:
+		case e.rule == "Slash" || previous == "Slash":
+			line.WriteString(" ")
+		case !e.spaced:
+		case previous == "Open" || previous == "Begin" || previous == "And" || previous == "Not" || previous == "Label":
+		case e.rule == "Close" || e.rule == "End" || e.rule == "Question" || e.rule == "Star" || e.rule == "Plus" || e.rule == "Repetition":
+		case e.rule == "Action":
+			line.WriteString(padding(line.String(), e.column))
+		default:
+			line.WriteString(" ")
+		}
+		switch e.rule {
+		case "Open", "Begin":
+			depth++
+		case "Close", "End":
+			depth--
+		}
+		line.WriteString(e.text)
+		fresh, previous = false, e.rule
+	}
+	flush()
+	for length := len(lines); length > 0 && lines[length-1] == (output{}); length = len(lines) {
+		lines = lines[:length-1]
+	}
+
+	out := &strings.Builder{}
+	out.WriteString(header)
+	out.WriteString("\n")
+	for _, l := range lines {
+		out.WriteString(l.code)
+		if l.comment != "" {
+			out.WriteString(padding(l.code, l.column))
+			out.WriteString(l.comment)
+		}
+		out.WriteString("\n")
+	}
+	return out.String()
+}
+
+/* The spaces following the code of a line up to the column, or a single space when the code reaches it. */
+func padding(code string, column int) string {
+	if length := len([]rune(code)); length < column {
+		return strings.Repeat(" ", column-length)
+	}
+	return " "
+}
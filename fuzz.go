@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"text/template"
+)
+
+/* The go test fuzz target written by -fuzz next to a generated parser, checking the invariants of its parses. */
+const PEG_FUZZ_TEMPLATE = `package {{.PackageName}}
+
+import (
+	"testing"
+)
+
+// Prepares the state used by the actions of the parser before Execute is called, for instance
+// in an init function of another test file. Without it the actions aren't executed.
+var fuzzSetup func(p *{{.StructName}})
+
+// FuzzParse parses random inputs, starting from the seed corpus in testdata/fuzz/FuzzParse, checking
+// that the parser doesn't panic, that the tokens are nested and within the input, that parsing again
+// after Reset gives the same tokens{{if .HasActions}} and, once fuzzSetup is set, that Execute doesn't
+// panic{{end}}.
+func FuzzParse(f *testing.F) {
+	f.Add("")
+	f.Fuzz(func(t *testing.T, input string) {
+		p := &{{.StructName}}{Buffer: input}
+		p.Init()
+		parse := func() ([]token32, error) {
+			if {{if .HasValues}}_, {{end}}err := p.Parse(); err != nil {
+				return nil, err
+			}
+			var tokens []token32
+			for token := range p.Tokens() {
+				tokens = append(tokens, token)
+			}
+			return tokens, nil
+		}
+
+		tokens, err := parse()
+		length, stack := int32(len([]rune(input))), []token32{}
+		for _, token := range tokens {
+			if token.begin < 0 || token.begin > token.end || token.end > length {
+				t.Fatalf("token %v %v-%v isn't within the %v runes of the input", Rul3s[token.Rule], token.begin, token.end, length)
+			}
+			/* the tokens of a rule come before the token of the rule containing them, one level deeper */
+			for ; len(stack) > 0 && stack[len(stack) - 1].next > token.next; stack = stack[:len(stack) - 1] {
+				if child := stack[len(stack) - 1]; child.begin < token.begin || child.end > token.end {
+					t.Fatalf("token %v %v-%v isn't within the token %v %v-%v containing it",
+						Rul3s[child.Rule], child.begin, child.end, Rul3s[token.Rule], token.begin, token.end)
+				}
+			}
+			stack = append(stack, token)
+		}
+
+		p.Reset()
+		again, errAgain := parse()
+		if (err == nil) != (errAgain == nil) {
+			t.Fatalf("parsing again after Reset returned %v instead of %v", errAgain, err)
+		}
+		if len(again) != len(tokens) {
+			t.Fatalf("parsing again after Reset gave %v tokens instead of %v", len(again), len(tokens))
+		}
+		for i, token := range tokens {
+			if u := again[i]; u != token {
+				t.Fatalf("parsing again after Reset gave the token %v %v-%v instead of %v %v-%v",
+					Rul3s[u.Rule], u.begin, u.end, Rul3s[token.Rule], token.begin, token.end)
+			}
+		}
+		{{if .HasActions}}
+		if err == nil {
+			if fuzzSetup == nil {
+				t.Log("fuzzSetup isn't set, so the actions aren't executed")
+				return
+			}
+			fuzzSetup(p)
+			p.Execute()
+		}
+		{{end}}
+	})
+}
+`
+
+/* WriteFuzzTest writes the fuzz target of the parser generated by Compile into the test file. */
+func (t *Tree) WriteFuzzTest(file string) error {
+	var buffer bytes.Buffer
+	if err := template.Must(template.New("fuzz").Parse(PEG_FUZZ_TEMPLATE)).Execute(&buffer, t); err != nil {
+		return err
+	}
+	fileSet := token.NewFileSet()
+	code, err := parser.ParseFile(fileSet, file, &buffer, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+	out, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	formatter := printer.Config{Mode: printer.TabIndent | printer.UseSpaces, Tabwidth: 8}
+	return formatter.Fprint(out, fileSet, code)
+}
@@ -0,0 +1,383 @@
+package main
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"unicode/utf8"
+)
+
+/* The height of an expression that can't be generated without recursing forever. */
+const generateUnbounded = 1 << 20
+
+/* The characters generated for a dot, printable ASCII and some spacing. */
+const generateCharacters = " \t\n !\"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\\]^_`abcdefghijklmnopqrstuvwxyz{|}~"
+
+/* Derives random text from the rules of a prepared tree. Below the depth or beyond the length, the
+   choices that reach terminals with the fewest rule calls are taken, so that the text ends. */
+type generator struct {
+	*Tree
+	random          *rand.Rand
+	heights         map[string]int
+	depth, maximum  int
+	length          int
+	text            []rune
+	captures        []runCapture
+	frame           int
+	rejections      []generateRejection
+	firsts, singles map[Node]*set
+}
+
+/* A negative predicate met at a position of the text, rejecting what is generated there. */
+type generateRejection struct {
+	predicate Node
+	position  int
+}
+
+/* The fewest rule calls nested to generate text for an expression, given the heights of the rules. */
+func (g *generator) height(n Node) int {
+	switch n.GetType() {
+	case TypeName:
+		if height, ok := g.heights[n.String()]; ok {
+			return height
+		}
+		return generateUnbounded
+	case TypeImplicitPush, TypePush, TypeLabel, TypePlus, TypePrecedence:
+		return g.height(n.Front())
+	case TypeRepeat:
+		if min, _ := repetitionBounds(n); min > 0 {
+			return g.height(n.Front())
+		}
+	case TypeAlternate, TypeUnorderedAlternate:
+		height := generateUnbounded
+		for _, element := range n.Slice() {
+			if h := g.height(element); h < height {
+				height = h
+			}
+		}
+		return height
+	case TypeSequence:
+		height := 0
+		for _, element := range n.Slice() {
+			if h := g.height(element); h > height {
+				height = h
+			}
+		}
+		return height
+	}
+	return 0
+}
+
+/* The characters text matching the expression could start with. */
+func (g *generator) first(n Node) *set {
+	if s, ok := g.firsts[n]; ok {
+		return s
+	}
+	s := &set{}
+	g.firsts[n] = s
+	switch n.GetType() {
+	case TypeDot, TypeCharacter, TypeRange:
+		s.union(g.single(n))
+	case TypeString:
+		if c, _ := utf8.DecodeRuneInString(n.String()); c < 256 {
+			s.add(uint8(c))
+		}
+	case TypeName:
+		s.union(g.first(g.Rules[n.String()].Front()))
+	case TypeImplicitPush, TypePush, TypeLabel, TypePlus, TypeQuery, TypeStar, TypeRepeat, TypePrecedence:
+		s.union(g.first(n.Front()))
+	case TypeAlternate, TypeUnorderedAlternate:
+		for _, element := range n.Slice() {
+			s.union(g.first(element))
+		}
+	case TypeSequence:
+		/* the single characters rejected by negative predicates before the first element are excluded */
+		excluded := &set{}
+		for _, element := range n.Slice() {
+			switch element.GetType() {
+			case TypePeekNot:
+				excluded.union(g.single(element.Front()))
+				continue
+			case TypePredicate, TypeAction, TypeNil, TypeCommit, TypePeekFor:
+				continue
+			}
+			s.union(g.first(element))
+			excluded.complement()
+			s.intersection(excluded)
+			break
+		}
+	}
+	return s
+}
+
+/* The characters the expression surely matches by themselves, as a class does. */
+func (g *generator) single(n Node) *set {
+	if s, ok := g.singles[n]; ok {
+		return s
+	}
+	s := &set{}
+	g.singles[n] = s
+	switch n.GetType() {
+	case TypeDot:
+		s.complement()
+	case TypeCharacter:
+		if c, _ := utf8.DecodeRuneInString(n.String()); c < 256 {
+			s.add(uint8(c))
+		}
+	case TypeRange:
+		lower, _ := utf8.DecodeRuneInString(n.Front().String())
+		upper, _ := utf8.DecodeRuneInString(n.Front().Next().String())
+		for c := lower; c <= upper && c < 256; c++ {
+			s.add(uint8(c))
+		}
+	case TypeName:
+		s.union(g.single(g.Rules[n.String()].Front()))
+	case TypeImplicitPush:
+		s.union(g.single(n.Front()))
+	case TypeAlternate, TypeUnorderedAlternate:
+		for _, element := range n.Slice() {
+			s.union(g.single(element))
+		}
+	}
+	return s
+}
+
+/* Reports whether negative predicates were met at the end of the text. */
+func (g *generator) pending() bool {
+	for _, r := range g.rejections {
+		if r.position == len(g.text) {
+			return true
+		}
+	}
+	return false
+}
+
+/* Reports whether a negative predicate met at the end of the text rejects the character. */
+func (g *generator) rejected(c rune) bool {
+	for _, r := range g.rejections {
+		if r.position == len(g.text) && c < 256 && g.first(r.predicate).has(uint8(c)) {
+			return true
+		}
+	}
+	return false
+}
+
+/* Reports whether the negative predicates met at the end of the text reject every character the expression starts with. */
+func (g *generator) conflicts(n Node) bool {
+	if !g.pending() {
+		return false
+	}
+	first, starts := g.first(n), false
+	for c := 0; c < 256; c++ {
+		if first.has(uint8(c)) {
+			if !g.rejected(rune(c)) {
+				return false
+			}
+			starts = true
+		}
+	}
+	return starts
+}
+
+/* Rejects what a negative predicate, or a repetition that would have gone on, matches at the end of the text. */
+func (g *generator) reject(n Node) {
+	rejections := g.rejections[:0]
+	for _, r := range g.rejections {
+		if r.position == len(g.text) {
+			rejections = append(rejections, r)
+		}
+	}
+	g.rejections = append(rejections, generateRejection{n, len(g.text)})
+}
+
+/* A random character from lower up to upper, one the negative predicates met before don't reject if possible. */
+func (g *generator) character(lower, upper rune) (c rune) {
+	for try := 0; try < 16; try++ {
+		if lower == 0 {
+			c = rune(generateCharacters[g.random.Intn(len(generateCharacters))])
+		} else {
+			c = lower + rune(g.random.Intn(int(upper-lower)+1))
+		}
+		if !g.rejected(c) {
+			break
+		}
+	}
+	return
+}
+
+/* A random count of repetitions of an expression, more of them being less likely, and less so the deeper
+   the calls. One more short one, such as spacing, is generated when it keeps what follows from being
+   rejected by a negative predicate. */
+func (g *generator) repetitions(n Node, min, max int) int {
+	count := min
+	if (max < 0 || count < max) && g.height(n) <= 1 && len(g.text) < g.length && g.pending() && !g.conflicts(n) {
+		count++
+	}
+	for (max < 0 || count < max) && len(g.text) < g.length && g.random.Intn(2*g.maximum) >= g.maximum+g.depth {
+		count++
+	}
+	return count
+}
+
+/* Appends random text matching the expression, approximately when it has predicates. */
+func (g *generator) generate(n Node) {
+	switch n.GetType() {
+	case TypeDot:
+		g.text = append(g.text, g.character(0, 0))
+	case TypeName:
+		frame := g.frame
+		g.frame = len(g.captures)
+		g.depth++
+		g.generate(g.Rules[n.String()].Front())
+		g.depth--
+		g.captures, g.frame = g.captures[:g.frame], frame
+	case TypeRange:
+		lower, _ := utf8.DecodeRuneInString(n.Front().String())
+		upper, _ := utf8.DecodeRuneInString(n.Front().Next().String())
+		g.text = append(g.text, g.character(lower, upper))
+	case TypeCharacter, TypeString:
+		g.text = append(g.text, []rune(n.String())...)
+	case TypeBackReference:
+		for i := len(g.captures) - 1; i >= g.frame; i-- {
+			if capture := g.captures[i]; capture.name == n.String() {
+				g.text = append(g.text, g.text[capture.begin:capture.end]...)
+				break
+			}
+		}
+	case TypeImplicitPush, TypePush:
+		g.generate(n.Front())
+	case TypeLabel:
+		begin := len(g.text)
+		g.generate(n.Front())
+		g.captures = append(g.captures, runCapture{n.String(), begin, len(g.text)})
+	case TypeQuery, TypeStar, TypePlus, TypeRepeat:
+		min, max := 0, -1
+		switch n.GetType() {
+		case TypeQuery:
+			max = 1
+		case TypePlus:
+			min = 1
+		case TypeRepeat:
+			min, max = repetitionBounds(n)
+		}
+		count := g.repetitions(n.Front(), min, max)
+		for i := 0; i < count; i++ {
+			g.generate(n.Front())
+		}
+		/* the repetition is greedy, so what follows it can't start like it */
+		if max < 0 || count < max {
+			g.reject(n.Front())
+		}
+	case TypePrecedence:
+		g.generate(n.Front())
+		levels := n.Slice()[1:]
+		for count := g.repetitions(levels[0].Front(), 0, -1); count > 0; count-- {
+			operators := levels[g.random.Intn(len(levels))].Slice()
+			g.generate(operators[g.random.Intn(len(operators))])
+			g.generate(n.Front())
+		}
+	case TypeAlternate, TypeUnorderedAlternate:
+		/* the alternatives not going deeper than the depth, or else the shallowest ones, and of
+		   those the ones a negative predicate met before doesn't reject */
+		var choices, accepted []*node
+		least := generateUnbounded
+		for _, element := range n.Slice() {
+			height := g.height(element)
+			if g.depth+height <= g.maximum && len(g.text) < g.length {
+				choices = append(choices, element)
+			} else if height < least {
+				least = height
+			}
+		}
+		if len(choices) == 0 {
+			for _, element := range n.Slice() {
+				if g.height(element) == least {
+					choices = append(choices, element)
+				}
+			}
+		}
+		for _, element := range choices {
+			if !g.conflicts(element) {
+				accepted = append(accepted, element)
+			}
+		}
+		if len(accepted) > 0 {
+			choices = accepted
+		}
+		g.generate(choices[g.random.Intn(len(choices))])
+	case TypePeekNot:
+		g.reject(n.Front())
+	case TypeSequence:
+		for _, element := range n.Slice() {
+			g.generate(element)
+		}
+	}
+}
+
+/* GenerateInputs derives count random inputs from the first rule of the grammar, printing them quoted one per line,
+   or with a corpus directory writing them into it as the seed corpus of a go test fuzz target. The same seed
+   generates the same inputs. An input is generated again, up to ten times, until the grammar matches all of it. */
+func (t *Tree) GenerateInputs(out io.Writer, count int, seed int64, depth int, corpus string) error {
+	t._switch = false
+	t.prepare()
+	t.interpretable()
+	if len(t.RuleNames) == 0 {
+		return fmt.Errorf("the grammar has no rules")
+	}
+	if corpus != "" {
+		if err := os.MkdirAll(corpus, 0755); err != nil {
+			return err
+		}
+	}
+
+	g := &generator{Tree: t, random: rand.New(rand.NewSource(seed)), heights: make(map[string]int),
+		firsts: make(map[Node]*set), singles: make(map[Node]*set), maximum: depth, length: 1 << 11}
+	for changed := true; changed; {
+		changed = false
+		for _, rule := range t.RuleNames {
+			height := g.height(rule.Front()) + 1
+			if current, ok := g.heights[rule.String()]; height < generateUnbounded && (!ok || height < current) {
+				g.heights[rule.String()], changed = height, true
+			}
+		}
+	}
+	if _, ok := g.heights[t.RuleNames[0].String()]; !ok {
+		return fmt.Errorf("rule '%v' never ends", t.RuleNames[0])
+	}
+
+	start, unmatched := &node{Type: TypeName, string: t.RuleNames[0].String()}, 0
+	for i := 0; i < count; i++ {
+		var input string
+		for try := 0; try < 10; try++ {
+			g.text, g.captures, g.frame, g.rejections = g.text[:0], g.captures[:0], 0, g.rejections[:0]
+			g.generate(start)
+			input = string(g.text)
+			if m, err := t.interpret("", input, nil); err == nil && m.position == len(m.buffer)-1 {
+				break
+			} else if try == 9 {
+				unmatched++
+			}
+		}
+
+		if corpus == "" {
+			if _, err := fmt.Fprintln(out, strconv.Quote(input)); err != nil {
+				return err
+			}
+			continue
+		}
+		entry := []byte(fmt.Sprintf("go test fuzz v1\nstring(%q)\n", input))
+		name := fmt.Sprintf("%x", sha256.Sum256(entry))[:16]
+		if err := ioutil.WriteFile(filepath.Join(corpus, name), entry, 0644); err != nil {
+			return err
+		}
+	}
+	if unmatched > 0 {
+		fmt.Fprintf(os.Stderr, "%v of the %v inputs don't match the grammar\n", unmatched, count)
+	}
+	return nil
+}
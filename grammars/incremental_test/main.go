@@ -0,0 +1,86 @@
+// Copyright 2010 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"os"
+)
+
+/* Applies random edits with Reparse and compares the result with a full parse of the edited text. */
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Printf("%v FILE...\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	compare := func(incremental *Java, err error) {
+		full := &Java{Buffer: incremental.Buffer}
+		full.Init()
+		if expected := full.Parse(); (err == nil) != (expected == nil) {
+			log.Fatalf("reparse error %v, full parse error %v", err, expected)
+		} else if err != nil {
+			return
+		}
+
+		a, b := incremental.Tokens(), full.Tokens()
+		for token := range b {
+			if reparsed, ok := <-a; !ok {
+				log.Fatalf("missing token %v", token)
+			} else if reparsed != token {
+				log.Fatalf("token %v should be %v", reparsed, token)
+			}
+		}
+		if token, ok := <-a; ok {
+			log.Fatalf("extra token %v", token)
+		}
+	}
+
+	random := rand.New(rand.NewSource(1))
+	for _, name := range os.Args[1:] {
+		buffer, err := ioutil.ReadFile(name)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		java := &Java{Buffer: string(buffer)}
+		java.Init()
+		if err := java.Parse(); err != nil {
+			log.Fatal(err)
+		}
+
+		fails := 0
+		for c := 0; c < 1000; c++ {
+			text := []rune(java.Buffer)
+			begin := random.Intn(len(text))
+			end := begin + random.Intn(8)
+			if end > len(text) {
+				end = len(text)
+			}
+			from := random.Intn(len(text))
+			to := from + random.Intn(8)
+			if to > len(text) {
+				to = len(text)
+			}
+
+			/* replace a span with text from elsewhere in the file, then undo the edit */
+			edit := Edit{Begin: begin, End: end, Text: string(text[from:to])}
+			err := java.Reparse(edit)
+			if err != nil {
+				fails++
+			}
+			compare(java, err)
+			undo := Edit{Begin: begin, End: begin + to - from, Text: string(text[begin:end])}
+			compare(java, java.Reparse(undo))
+			if java.Buffer != string(text) {
+				log.Fatalf("undo of %v failed", edit)
+			}
+		}
+		fmt.Printf("%v: 2000 reparses, %v edits rejected\n", name, fails)
+	}
+}
@@ -0,0 +1,707 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+/* Reads a grammar written for leg, pigeon or pegen, the parser generator of CPython, into the tree
+   with the same builder methods the parser of peg.peg calls. */
+type importer struct {
+	*Tree
+	format, file string
+	buffer       []rune
+	position     int
+	actions      int
+	/* where each rule is defined */
+	starts map[string]int
+}
+
+/* A syntax error, recovered by Import. */
+type importError struct {
+	error
+}
+
+func (p *importer) line(position int) int {
+	return 1 + strings.Count(string(p.buffer[:position]), "\n")
+}
+
+/* Reports a construct that can't be translated. */
+func (p *importer) warn(position int, format string, a ...interface{}) {
+	fmt.Fprintf(os.Stderr, "%v:%v: %v\n", p.file, p.line(position), fmt.Sprintf(format, a...))
+}
+
+func (p *importer) fail(format string, a ...interface{}) {
+	panic(importError{fmt.Errorf("%v:%v: %v", p.file, p.line(p.position), fmt.Sprintf(format, a...))})
+}
+
+func (p *importer) end() bool {
+	return p.position >= len(p.buffer)
+}
+
+func (p *importer) has(s string) bool {
+	for i, c := range []rune(s) {
+		if p.position+i >= len(p.buffer) || p.buffer[p.position+i] != c {
+			return false
+		}
+	}
+	return true
+}
+
+/* Skips white space and comments, which start with # in leg and pegen, and with // or /* in pigeon. */
+func (p *importer) space() {
+	for !p.end() {
+		switch {
+		case unicode.IsSpace(p.buffer[p.position]):
+			p.position++
+		case p.format == "pigeon" && p.has("/*"):
+			for p.position += 2; !p.end() && !p.has("*/"); p.position++ {
+			}
+			p.position += 2
+		case p.format == "pigeon" && p.has("//"), p.format != "pigeon" && p.has("#"):
+			for !p.end() && p.buffer[p.position] != '\n' {
+				p.position++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (p *importer) match(s string) bool {
+	p.space()
+	if p.has(s) {
+		p.position += len([]rune(s))
+		return true
+	}
+	return false
+}
+
+func (p *importer) expect(s string) {
+	if !p.match(s) {
+		p.fail("expected %q", s)
+	}
+}
+
+/* The column of the next token, rules of pegen start in the first column. */
+func (p *importer) column() int {
+	p.space()
+	column := 0
+	for i := p.position - 1; i >= 0 && p.buffer[i] != '\n'; i-- {
+		column++
+	}
+	return column
+}
+
+/* Identifiers of leg may contain dashes, they are replaced with underscores. */
+func (p *importer) identifier() string {
+	p.space()
+	start := p.position
+	for !p.end() {
+		c := p.buffer[p.position]
+		if c == '_' || unicode.IsLetter(c) || p.position > start && unicode.IsDigit(c) || p.format == "leg" && c == '-' {
+			p.position++
+			continue
+		}
+		break
+	}
+	return strings.Replace(string(p.buffer[start:p.position]), "-", "_", -1)
+}
+
+/* Whether a definition starts at the next token, which ends the expression before it. */
+func (p *importer) definition() bool {
+	position := p.position
+	defer func() { p.position = position }()
+	switch p.format {
+	case "pegen":
+		return p.column() == 0 && !p.end()
+	case "leg":
+		return p.identifier() != "" && p.match("=")
+	}
+	if p.identifier() == "" {
+		return false
+	}
+	if p.space(); p.has("\"") || p.has("'") || p.has("`") {
+		p.literal()
+	}
+	return p.match("<-") || p.match("=") || p.match("←") || p.match("⟵")
+}
+
+/* Skips a block of code in braces, which may contain strings and nested braces. */
+func (p *importer) code() string {
+	p.space()
+	start, depth := p.position, 0
+	for ; !p.end(); p.position++ {
+		switch c := p.buffer[p.position]; c {
+		case '{':
+			depth++
+		case '}':
+			if depth--; depth == 0 {
+				p.position++
+				return string(p.buffer[start:p.position])
+			}
+		case '"', '\'', '`':
+			for p.position++; !p.end() && p.buffer[p.position] != c && p.buffer[p.position] != '\n'; p.position++ {
+				if p.buffer[p.position] == '\\' && c != '`' {
+					p.position++
+				}
+			}
+		}
+	}
+	p.fail("unterminated code block")
+	return ""
+}
+
+/* Reads an escaped character of a literal or a class. */
+func (p *importer) character() rune {
+	c := p.buffer[p.position]
+	p.position++
+	if c != '\\' || p.end() {
+		return c
+	}
+	c = p.buffer[p.position]
+	p.position++
+	switch c {
+	case 'a':
+		return '\a'
+	case 'b':
+		return '\b'
+	case 'e':
+		return '\x1B'
+	case 'f':
+		return '\f'
+	case 'n':
+		return '\n'
+	case 'r':
+		return '\r'
+	case 't':
+		return '\t'
+	case 'v':
+		return '\v'
+	case 'x', 'u', 'U':
+		digits := map[rune]int{'x': 2, 'u': 4, 'U': 8}[c]
+		if p.position+digits <= len(p.buffer) {
+			if value, err := strconv.ParseUint(string(p.buffer[p.position:p.position+digits]), 16, 32); err == nil {
+				p.position += digits
+				return rune(value)
+			}
+		}
+	case '0', '1', '2', '3', '4', '5', '6', '7':
+		start := p.position - 1
+		for p.position < len(p.buffer) && p.position < start+3 && p.buffer[p.position] >= '0' && p.buffer[p.position] <= '7' {
+			p.position++
+		}
+		value, _ := strconv.ParseUint(string(p.buffer[start:p.position]), 8, 32)
+		return rune(value)
+	}
+	return c
+}
+
+/* Reads a quoted literal, the suffix i of pigeon makes it case insensitive. */
+func (p *importer) literal() (text []rune, insensitive bool) {
+	p.space()
+	quote := p.buffer[p.position]
+	if p.format == "pegen" && (p.has(`"""`) || p.has("'''")) {
+		p.fail("triple quoted strings can't be translated")
+	}
+	for p.position++; !p.end() && p.buffer[p.position] != quote; {
+		if quote == '`' {
+			text = append(text, p.buffer[p.position])
+			p.position++
+			continue
+		}
+		text = append(text, p.character())
+	}
+	if p.end() {
+		p.fail("unterminated literal")
+	}
+	p.position++
+	if p.format == "pigeon" && p.has("i") {
+		p.position++
+		insensitive = true
+	}
+	return
+}
+
+func (p *importer) addLiteral(text []rune, insensitive bool) {
+	if len(text) == 0 {
+		p.AddNil()
+		return
+	}
+	for i, c := range text {
+		if insensitive && unicode.IsLetter(c) {
+			p.AddDoubleCharacter(string(c))
+		} else {
+			p.AddCharacter(string(c))
+		}
+		if i > 0 {
+			p.AddSequence()
+		}
+	}
+}
+
+/* Reads a class, the unicode classes of pigeon are replaced with a dot. */
+func (p *importer) class() {
+	start := p.position
+	p.expect("[")
+	negated, ranges := p.has("^"), [][2]rune{}
+	if negated {
+		p.position++
+	}
+	unicodes := false
+	for !p.end() && p.buffer[p.position] != ']' {
+		if p.format == "pigeon" && (p.has("\\p") || p.has("\\P")) {
+			p.position += 2
+			if p.has("{") {
+				for !p.end() && p.buffer[p.position] != '}' {
+					p.position++
+				}
+			}
+			p.position++
+			unicodes = true
+			continue
+		}
+		lower := p.character()
+		if p.has("-") && !p.has("-]") {
+			p.position++
+			ranges = append(ranges, [2]rune{lower, p.character()})
+		} else {
+			ranges = append(ranges, [2]rune{lower, lower})
+		}
+	}
+	if p.end() {
+		p.fail("unterminated class")
+	}
+	p.position++
+	insensitive := p.format == "pigeon" && p.has("i")
+	if insensitive {
+		p.position++
+	}
+
+	switch {
+	case unicodes:
+		p.warn(start, "unicode class %v can't be translated, replaced with .", string(p.buffer[start:p.position]))
+		p.AddDot()
+		return
+	case len(ranges) == 0:
+		p.warn(start, "empty class can't be translated, replaced with !.")
+		p.AddDot()
+		p.AddPeekNot()
+		return
+	}
+	for i, r := range ranges {
+		switch {
+		case r[0] == r[1] && insensitive && unicode.IsLetter(r[0]):
+			p.AddDoubleCharacter(string(r[0]))
+		case r[0] == r[1]:
+			p.AddCharacter(string(r[0]))
+		case insensitive && unicode.IsLetter(r[0]) && unicode.IsLetter(r[1]):
+			p.AddCharacter(string(r[0]))
+			p.AddCharacter(string(r[1]))
+			p.AddDoubleRange()
+		default:
+			p.AddCharacter(string(r[0]))
+			p.AddCharacter(string(r[1]))
+			p.AddRange()
+		}
+		if i > 0 {
+			p.AddAlternate()
+		}
+	}
+	if negated {
+		p.AddPeekNot()
+		p.AddDot()
+		p.AddSequence()
+	}
+}
+
+func (p *importer) alternative() bool {
+	switch p.format {
+	case "leg":
+		return p.match("|") || p.match("/")
+	case "pigeon":
+		return p.match("/")
+	}
+	return p.match("|")
+}
+
+func (p *importer) expression() {
+	if p.format == "pegen" {
+		p.match("|")
+	}
+	for alternates := 1; ; alternates++ {
+		p.sequence()
+		if alternates > 1 {
+			p.AddAlternate()
+		}
+		if !p.alternative() {
+			return
+		}
+	}
+}
+
+func (p *importer) sequence() {
+	items := 0
+	for {
+		p.space()
+		if p.end() || p.has(")") || p.has("]") || p.has("|") || p.has("/") && p.format != "pegen" ||
+			p.has(">") && p.format == "leg" || p.has(";") && p.format != "pegen" || p.has("%") && p.format == "leg" || p.definition() {
+			break
+		}
+		if p.prefix() {
+			if items++; items > 1 {
+				p.AddSequence()
+			}
+		}
+	}
+	if items == 0 {
+		p.AddNil()
+	}
+}
+
+/* Reads a prefix expression, returning false when it can't be translated and adds nothing. */
+func (p *importer) prefix() bool {
+	p.space()
+	start := p.position
+	switch {
+	case p.has("{"):
+		p.code()
+		p.actions++
+		return false
+	case p.has("&{") || p.has("!{") || p.has("@{") || p.has("~{") || p.has("#{"):
+		p.position++
+		p.warn(start, "%v code block can't be translated, dropped", string(p.buffer[start]))
+		p.code()
+		return false
+	case p.format == "pegen" && p.match("&&"):
+		p.warn(start, "forced item &&, translated as the item")
+		p.suffix()
+		return true
+	case p.format == "pegen" && p.match("~"):
+		p.AddCommit()
+		return true
+	case p.match("&"):
+		p.suffix()
+		p.AddPeekFor()
+		return true
+	case p.match("!"):
+		p.suffix()
+		p.AddPeekNot()
+		return true
+	}
+
+	/* variables of leg, labels of pigeon and named items of pegen */
+	separator := ":"
+	if p.format == "pegen" {
+		separator = "="
+	}
+	if label := p.identifier(); label != "" && p.match(separator) && !p.has("=") {
+		p.AddLabel(label)
+		if !p.prefix() {
+			p.AddNil()
+		}
+		p.AddLabeled()
+		return true
+	}
+	p.position = start
+	p.suffix()
+	return true
+}
+
+func (p *importer) suffix() {
+	p.primary()
+	switch {
+	case p.match("?"):
+		p.AddQuery()
+	case p.match("*"):
+		p.AddStar()
+	case p.match("+"):
+		p.AddPlus()
+	case p.format == "pegen" && p.match("."):
+		/* the gather s.e+ is e (s e)*, the element is read again for its second copy */
+		position := p.position
+		p.primary()
+		p.expect("+")
+		end, element := p.position, p.PopFront()
+		separator := p.PopFront()
+		p.PushFront(element)
+		p.PushFront(separator)
+		p.position = position
+		p.primary()
+		p.position = end
+		p.AddSequence()
+		p.AddStar()
+		p.AddSequence()
+	}
+}
+
+func (p *importer) primary() {
+	p.space()
+	switch {
+	case p.end():
+		p.fail("unexpected end of grammar")
+	case p.match("("):
+		p.expression()
+		p.expect(")")
+	case p.format == "pegen" && p.match("["):
+		p.expression()
+		p.expect("]")
+		p.AddQuery()
+	case p.has("["):
+		p.class()
+	case p.format == "leg" && p.match("<"):
+		p.expression()
+		p.expect(">")
+		p.AddPush()
+	case p.format != "pegen" && p.match("."):
+		p.AddDot()
+	case p.has("\"") || p.has("'") || p.format == "pigeon" && p.has("`"):
+		p.addLiteral(p.literal())
+	default:
+		name := p.identifier()
+		if name == "" {
+			p.fail("unexpected %q", string(p.buffer[p.position]))
+		}
+		p.AddName(name)
+	}
+}
+
+/* Skips the declarations of leg and the meta lines of pegen, reporting them. */
+func (p *importer) declaration() bool {
+	p.space()
+	start := p.position
+	switch {
+	case p.format == "leg" && p.match("%{"):
+		for !p.end() && !p.has("%}") {
+			p.position++
+		}
+		p.position += 2
+		p.warn(start, "%%{ %%} declarations can't be translated, dropped")
+	case p.format == "leg" && p.match("%%"):
+		p.position = len(p.buffer)
+		p.warn(start, "%%%% trailer can't be translated, dropped")
+	case p.format == "pegen" && p.match("@"):
+		p.identifier()
+		for !p.end() && p.buffer[p.position] != '\n' {
+			if p.has(`"""`) || p.has("'''") {
+				quote := string(p.buffer[p.position : p.position+3])
+				for p.position += 3; !p.end() && !p.has(quote); p.position++ {
+				}
+				p.position += 2
+			}
+			p.position++
+		}
+		p.warn(start, "meta line can't be translated, dropped")
+	default:
+		return false
+	}
+	return true
+}
+
+func (p *importer) rule() {
+	p.space()
+	start := p.position
+	name := p.identifier()
+	if name == "" {
+		p.fail("expected a rule")
+	}
+	p.AddRule(name)
+	p.starts[name] = start
+	switch p.format {
+	case "leg":
+		p.expect("=")
+	case "pigeon":
+		if p.space(); p.has("\"") || p.has("'") || p.has("`") {
+			display, _ := p.literal()
+			p.warn(start, "rule %v display name %q dropped", name, string(display))
+		}
+		if !p.match("<-") && !p.match("=") && !p.match("←") && !p.match("⟵") {
+			p.fail("expected <- after rule %v", name)
+		}
+	case "pegen":
+		if p.space(); p.has("[") {
+			for !p.end() && !p.has("]") {
+				p.position++
+			}
+			p.position++
+		}
+		if p.match("(") {
+			p.warn(start, "rule %v annotation (%v) dropped", name, p.identifier())
+			p.expect(")")
+		}
+		p.expect(":")
+	}
+	p.expression()
+	p.match(";")
+	p.AddExpression()
+}
+
+/* Import reads a grammar written for leg, pigeon or pegen into the tree, reporting the constructs that
+   can't be translated. The actions are code for other languages and are dropped. */
+func (t *Tree) Import(format, file, source string) (err error) {
+	switch format {
+	case "leg", "pigeon", "pegen":
+	default:
+		return fmt.Errorf("unknown import format %q, expected leg, pigeon or pegen", format)
+	}
+	p := &importer{Tree: t, format: format, file: file, buffer: []rune(source), starts: make(map[string]int)}
+	defer func() {
+		if e := recover(); e != nil {
+			failure, ok := e.(importError)
+			if !ok {
+				panic(e)
+			}
+			err = failure.error
+		}
+	}()
+
+	name := []rune{}
+	for _, c := range strings.SplitN(filepath.Base(file), ".", 2)[0] {
+		if c == '_' || unicode.IsLetter(c) || len(name) > 0 && unicode.IsDigit(c) {
+			name = append(name, c)
+		}
+	}
+	if len(name) == 0 {
+		name = []rune("Grammar")
+	}
+	name[0] = unicode.ToUpper(name[0])
+	t.AddPackage("main")
+	t.AddPeg(string(name))
+	t.AddState("")
+
+	if p.space(); format == "pigeon" && p.has("{") {
+		p.warn(p.position, "initializer can't be translated, dropped")
+		p.code()
+	}
+	for p.space(); !p.end(); p.space() {
+		if !p.declaration() {
+			p.rule()
+		}
+	}
+	if p.actions > 0 {
+		fmt.Fprintf(os.Stderr, "%v: %v actions can't be translated, dropped\n", file, p.actions)
+	}
+
+	/* pegen parses left recursion, peg would call the rules forever */
+	var rules []Node
+	for _, n := range t.Slice() {
+		if n.GetType() == TypeRule {
+			rules = append(rules, n)
+		}
+	}
+	if recursive := leftRecursive(rules); len(recursive) > 0 {
+		sort.Slice(recursive, func(i, j int) bool { return p.starts[recursive[i]] < p.starts[recursive[j]] })
+		p.position = p.starts[recursive[0]]
+		p.fail("left recursive rules %v can't be translated", strings.Join(recursive, ", "))
+	}
+
+	/* the tokens of pegen come from the tokenizer of CPython and have no rules */
+	defined, undefined := make(map[string]bool), []string{}
+	for _, n := range t.Slice() {
+		if n.GetType() == TypeRule {
+			defined[n.String()] = true
+		}
+	}
+	for _, n := range t.Slice() {
+		if n.GetType() == TypeRule {
+			walk(n.Front(), func(n Node) {
+				if name := n.String(); n.GetType() == TypeName && !defined[name] {
+					defined[name] = true
+					undefined = append(undefined, name)
+				}
+			})
+		}
+	}
+	if len(undefined) > 0 {
+		fmt.Fprintf(os.Stderr, "%v: undefined rules %v match the empty string\n", file, strings.Join(undefined, ", "))
+	}
+	return nil
+}
+
+/* The rules that can call themselves before matching anything. Undefined rules, the tokens of pegen,
+   are taken to match something. */
+func leftRecursive(rules []Node) (recursive []string) {
+	definitions, nullable := make(map[string]Node), make(map[string]bool)
+	for _, rule := range rules {
+		definitions[rule.String()] = rule.Front()
+	}
+	var empty func(n Node) bool
+	empty = func(n Node) bool {
+		switch n.GetType() {
+		case TypeName:
+			return nullable[n.String()]
+		case TypeAlternate:
+			for _, element := range n.Slice() {
+				if empty(element) {
+					return true
+				}
+			}
+			return false
+		case TypeSequence:
+			for _, element := range n.Slice() {
+				if !empty(element) {
+					return false
+				}
+			}
+			return true
+		case TypePlus, TypePush, TypeLabel:
+			return empty(n.Front())
+		case TypeRepeat:
+			min, _ := repetitionBounds(n)
+			return min == 0 || empty(n.Front())
+		case TypePeekFor, TypePeekNot, TypeQuery, TypeStar, TypeAction, TypeCommit, TypeNil:
+			return true
+		}
+		return false
+	}
+	for changed := true; changed; {
+		changed = false
+		for name, definition := range definitions {
+			if !nullable[name] && empty(definition) {
+				nullable[name], changed = true, true
+			}
+		}
+	}
+
+	/* visits the rules called before the expression matches anything */
+	var left func(n Node, visit func(name string))
+	left = func(n Node, visit func(name string)) {
+		switch n.GetType() {
+		case TypeName:
+			visit(n.String())
+		case TypeAlternate:
+			for _, element := range n.Slice() {
+				left(element, visit)
+			}
+		case TypeSequence:
+			for _, element := range n.Slice() {
+				if left(element, visit); !empty(element) {
+					return
+				}
+			}
+		case TypePeekFor, TypePeekNot, TypeQuery, TypeStar, TypePlus, TypeRepeat, TypePush, TypeLabel:
+			left(n.Front(), visit)
+		}
+	}
+	for _, rule := range rules {
+		reached := make(map[string]bool)
+		var reach func(name string)
+		reach = func(name string) {
+			if !reached[name] {
+				reached[name] = true
+				if definition, ok := definitions[name]; ok {
+					left(definition, reach)
+				}
+			}
+		}
+		if left(rule.Front(), reach); reached[rule.String()] {
+			recursive = append(recursive, rule.String())
+		}
+	}
+	return
+}
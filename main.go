@@ -9,7 +9,12 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"os"
+	"path/filepath"
+	"regexp"
 	"runtime"
+	"runtime/debug"
+	"strings"
 	"time"
 )
 
@@ -20,25 +25,97 @@ var (
 	highlight = flag.Bool("highlight", false, "test the syntax highlighter")
 	test = flag.Bool("test", false, "test the PEG parser performance")
 	print = flag.Bool("print", false, "directly dump the syntax tree")
+	incremental = flag.Bool("incremental", false, "generate a parser supporting incremental reparsing")
+	stream = flag.Bool("stream", false, "generate a parser reading the records of the start rule from an io.Reader")
+	format = flag.Bool("fmt", false, "rewrite the grammar with its rules consistently aligned")
+	export = flag.String("export", "", "print the grammar as ebnf, w3c-ebnf, railroad or peg instead of generating a parser")
+	imports = flag.String("import", "", "read a grammar written for leg, pigeon or pegen")
+	run = flag.String("run", "", "interpret the grammar over the input instead of generating a parser")
+	input = flag.String("input", "", "the file read by -run, standard input by default, or the corpus of -bench")
+	trace = flag.Bool("trace", false, "generate a parser printing the calls of rules to its Trace writer")
+	profile = flag.Bool("profile", false, "generate a parser counting the calls of rules and the time spent in them")
+	repl = flag.String("repl", "", "read lines of input and print how the grammar parses each of them")
+	bench = flag.String("bench", "", "build the parser of the grammar with -inline and -switch and time each over the -input files")
+	cover = flag.Bool("cover", false, "generate a parser counting the matches of each rule and alternative of the grammar")
+	coverreport = flag.String("coverreport", "", "print the counts written by a parser generated with -cover onto the grammar")
+	htmlReport = flag.Bool("html", false, "print the -coverreport as an HTML page")
+	generate = flag.String("generate-inputs", "", "print random inputs derived from the grammar, or write them into the -corpus directory")
+	inputs = flag.Int("n", 1000, "the number of inputs -generate-inputs derives")
+	seed = flag.Int64("seed", 1, "the seed of the random choices of -generate-inputs")
+	depth = flag.Int("depth", 48, "the depth of rule calls beyond which -generate-inputs takes the shortest choices")
+	fuzz = flag.Bool("fuzz", false, "also write the go test fuzz target FuzzParse of the parser into <grammar>_fuzz_test.go")
+	check = flag.Bool("check", false, "fail when the parsers of the grammars are out of date or were generated by another version of peg")
+	update = flag.Bool("update", false, "generate the parser only when it isn't what peg would generate, for //go:generate")
+	corpus = flag.String("corpus", "", "the directory of the go test fuzz seed corpus written by -generate-inputs, such as testdata/fuzz/FuzzParse")
 )
 
+/* The version of peg recorded in the parsers it generates: the version of the module it was installed
+   from, or dev when it was built from a checkout. */
+var Version = "dev"
+
+/* The pseudo-version go gives a build of a commit that isn't tagged, such as
+   v0.0.0-20240102150405-abcdef123456, which would change with every commit. */
+var pseudoVersion = regexp.MustCompile(`[-.]\d{14}-[0-9a-f]{12}(\+incompatible)?$`)
+
+/* The version recorded for a build of peg from the module at the version, dev unless it is a release. */
+func releaseVersion(version string) string {
+	switch {
+	case version == "", version == "(devel)", strings.HasSuffix(version, "+dirty"), pseudoVersion.MatchString(version):
+		return "dev"
+	}
+	return version
+}
+
+func init() {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		Version = releaseVersion(info.Main.Version)
+	}
+}
+
 func main() {
 	runtime.GOMAXPROCS(2)
 	flag.Parse()
 
-	if flag.NArg() != 1 {
-		flag.Usage()
-		log.Fatalf("FILE: the peg file to compile")
+	if *check {
+		if flag.NArg() == 0 {
+			log.Fatalf("-check needs the peg files of the parsers to check")
+		}
+		if err := Check(flag.Args()); err != nil {
+			log.Fatal(err)
+		}
+		return
 	}
-	file := flag.Arg(0)
 
-	buffer, err := ioutil.ReadFile(file)
-	if err != nil {
-		log.Fatal(err)
+	file := ""
+	for _, f := range []string{*run, *repl, *bench, *generate} {
+		if f != "" {
+			file = f
+		}
+	}
+	if file == "" {
+		if flag.NArg() != 1 {
+			flag.Usage()
+			log.Fatalf("FILE: the peg file to compile")
+		}
+		file = flag.Arg(0)
 	}
 
+	if *coverreport != "" {
+		if err := CoverReport(os.Stdout, file, *coverreport, *htmlReport); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	options := Options{Inline: *inline, Switch: *_switch, Incremental: *incremental, Stream: *stream,
+		Tracing: *trace, Profiling: *profile, Covering: *cover}
+
 	if *test {
-		iterations, p := 1000, &Peg{Tree: New(*inline, *_switch), Buffer: string(buffer)}
+		buffer, err := ioutil.ReadFile(file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		iterations, p := 1000, &Peg{Tree: New(options), Buffer: string(buffer)}
 		p.Init()
 		start := time.Now()
 		for i := 0; i < iterations; i++ {
@@ -50,13 +127,90 @@ func main() {
 		return
 	}
 
-	p := &Peg{Tree: New(*inline, *_switch), Buffer: string(buffer)}
-	p.Init()
-	if err := p.Parse(); err != nil {
+	/* reads the grammar, or with -import a grammar written for another generator */
+	load := func() (*Peg, error) {
+		buffer, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		p := &Peg{Tree: New(options), Buffer: string(buffer)}
+		p.Init()
+		if *imports != "" {
+			return p, p.Import(*imports, file, string(buffer))
+		}
+		if err := p.Parse(); err != nil {
+			return nil, err
+		}
+		if !*format {
+			p.Execute()
+		}
+		return p, nil
+	}
+
+	tree := func() (*Tree, error) {
+		p, err := load()
+		if err != nil {
+			return nil, err
+		}
+		return p.Tree, nil
+	}
+	if *repl != "" {
+		if err := Repl(os.Stdin, os.Stdout, tree); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if *bench != "" {
+		if *input == "" {
+			log.Fatalf("-bench times the parser over the -input files")
+		}
+		if err := Bench(os.Stdout, file, *input, tree); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	p, err := load()
+	if err != nil {
 		log.Fatal(err)
 	}
 
-	p.Execute()
+	if *format && *imports == "" {
+		if err := ioutil.WriteFile(file, []byte(p.Format()), 0644); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if *export != "" {
+		if err := p.Export(os.Stdout, *export); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if *run != "" {
+		var text []byte
+		if *input == "" {
+			text, err = ioutil.ReadAll(os.Stdin)
+		} else {
+			text, err = ioutil.ReadFile(*input)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := p.Run(os.Stdout, "", string(text)); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if *generate != "" {
+		if err := p.GenerateInputs(os.Stdout, *inputs, *seed, *depth, *corpus); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 
 	if *print {
 		p.Print()
@@ -67,6 +221,32 @@ func main() {
 	if *highlight {
 		p.Highlighter()
 	}
+	/* the command recorded in the parser, with the options changing what is generated */
+	command := []string{"peg"}
+	for _, option := range []struct {
+		name string
+		set  bool
+	}{{"-inline", *inline}, {"-switch", *_switch}, {"-incremental", *incremental}, {"-stream", *stream},
+		{"-trace", *trace}, {"-profile", *profile}, {"-cover", *cover}, {"-fuzz", *fuzz},
+		{"-import=" + *imports, *imports != ""}} {
+		if option.set {
+			command = append(command, option.name)
+		}
+	}
+	p.Command, p.Version, p.Hash = strings.Join(append(command, filepath.Base(file)), " "), Version, grammarHash(p.Buffer)
+	fuzzTest := strings.TrimSuffix(file, ".peg") + "_fuzz_test.go"
+	if *update && stale(file, p.Command) == nil {
+		/* the fuzz target is written along with the parser, so it is missing only when it was removed */
+		if _, err := os.Stat(fuzzTest); !*fuzz || err == nil {
+			return
+		}
+	}
+
 	filename := file + ".go"
 	p.Compile(filename)
+	if *fuzz {
+		if err := p.WriteFuzzTest(fuzzTest); err != nil {
+			log.Fatal(err)
+		}
+	}
 }
@@ -11,12 +11,16 @@ import (
 	"go/printer"
 	"go/token"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"text/template"
 )
 
-const PEG_HEADER_TEMPLATE = `package {{.PackageName}}
+const PEG_HEADER_TEMPLATE = `// Code generated by {{if .Command}}{{.Command}}{{else}}peg{{end}}; DO NOT EDIT.
+{{if .Hash}}// peg version {{.Version}} sha256 {{.Hash}}{{end}}
+
+package {{.PackageName}}
 
 import (
 	/*"bytes"*/
@@ -24,6 +28,10 @@ import (
 	"math"
 	"sort"
 	"strconv"
+	{{if .Tracing}}"strings"{{end}}
+	{{if .Profiling}}"time"{{end}}
+	{{if or .Stream .Tracing .Profiling .Covering}}"io"{{end}}
+	{{if or .Stream .HasActions .HasValues}}"unicode/utf8"{{end}}
 )
 
 const END_SYMBOL rune = {{.EndSymbol}}
@@ -58,6 +66,7 @@ type TokenTree interface {
 	Tokens() <-chan token32
 	Error() []token32
 	trim(length int)
+	{{if .HasPrecedence}}deepen(begin, end int){{end}}
 }
 
 {{range .Sizes}}
@@ -91,8 +100,18 @@ type tokens{{.}} struct {
 
 func (t *tokens{{.}}) trim(length int) {
 	t.tree = t.tree[0:length]
+	t.ordered = nil
 }
 
+{{if $.HasPrecedence}}
+/* Moves the tokens from begin up to end a level down, below a token covering them. */
+func (t *tokens{{.}}) deepen(begin, end int) {
+	for i := begin; i < end; i++ {
+		t.tree[i].next++
+	}
+}
+{{end}}
+
 func (t *tokens{{.}}) Print() {
 	for _, token := range t.tree {
 		fmt.Println(token.String())
@@ -294,7 +313,9 @@ func (t *tokens{{.}}) Error() []token32 {
 
 func (t *tokens16) Expand(index int) TokenTree {
 	tree := t.tree
-	if index >= len(tree) {
+	if index < cap(tree) && index >= len(tree) {
+		t.tree = tree[:cap(tree)]
+	} else if index >= len(tree) {
 		expanded := make([]token32, 2 * len(tree))
 		for i, v := range tree {
 			expanded[i] = v.GetToken32()
@@ -306,7 +327,9 @@ func (t *tokens16) Expand(index int) TokenTree {
 
 func (t *tokens32) Expand(index int) TokenTree {
 	tree := t.tree
-	if index >= len(tree) {
+	if index < cap(tree) && index >= len(tree) {
+		t.tree = tree[:cap(tree)]
+	} else if index >= len(tree) {
 		expanded := make([]token32, 2 * len(tree))
 		copy(expanded, tree)
 		t.tree = expanded
@@ -319,11 +342,28 @@ type {{.StructName}} struct {
 	Buffer		string
 	buffer		[]rune
 	rules		[{{.RulesCount}}]func() bool
-	Parse		func(rule ...int) error
+	match		func(rule Rule, offset int) (int, bool)
+	Parse		func(rule ...int) {{if .HasValues}}(interface{}, error){{else}}error{{end}}
 	Reset		func()
+	{{if .Incremental}}Reparse		func(edit Edit) {{if .HasValues}}(interface{}, error){{else}}error{{end}}{{end}}
+	{{if .Stream}}ParseReader	func(reader io.Reader) error{{end}}
+	{{if .Tracing}}Trace		io.Writer{{end}}
+	{{if .Profiling}}Profile		[{{.RulesCount}}]RuleProfile{{end}}
 	TokenTree
 }
 
+{{if .Incremental}}
+/* An edit replaces the runes of the buffer from Begin up to End with Text. */
+type Edit struct {
+	Begin, End	int
+	Text		string
+}
+
+type reusableToken struct {
+	index, shift int
+}
+{{end}}
+
 type textPosition struct {
 	line, symbol int
 }
@@ -378,21 +418,229 @@ func (p *{{.StructName}}) Highlighter() {
 	p.TokenTree.PrintSyntax()
 }
 
+{{if .Profiling}}
+// The calls of a rule counted by a parser generated with -profile. Time includes the time spent
+// in the rules it calls and Self doesn't. Backtracks counts the times its code moved the position
+// back after an expression failed{{if .Incremental}}, and Reused the calls answered with the tokens
+// of the previous parse{{end}}.
+type RuleProfile struct {
+	Rule
+	Calls, Matches, Failures, Backtracks{{if .Incremental}}, Reused{{end}} int
+	Time, Self time.Duration
+}
+
+/* Prints the profiles of the rules called, the rule taking the most time by itself first. */
+func (p *{{.StructName}}) PrintProfile(w io.Writer) {
+	profile, width := make([]RuleProfile, 0, len(p.Profile)), len("rule")
+	for _, r := range p.Profile {
+		if r.Calls > 0 {
+			profile = append(profile, r)
+			if length := len(Rul3s[r.Rule]); length > width {
+				width = length
+			}
+		}
+	}
+	sort.Slice(profile, func(i, j int) bool { return profile[i].Self > profile[j].Self })
+	fmt.Fprintf(w, "%-*v %10v %10v %10v %10v{{if .Incremental}} %10v{{end}} %12v %12v\n", width, "rule",
+		"calls", "matches", "failures", "backtracks",{{if .Incremental}} "reused",{{end}} "time", "self")
+	for _, r := range profile {
+		fmt.Fprintf(w, "%-*v %10v %10v %10v %10v{{if .Incremental}} %10v{{end}} %12v %12v\n", width, Rul3s[r.Rule],
+			r.Calls, r.Matches, r.Failures, r.Backtracks,{{if .Incremental}} r.Reused,{{end}} r.Time, r.Self)
+	}
+}
+{{end}}
+
+{{if .Covering}}
+// The rules and the alternatives of {{.Grammar}}, as runes of the grammar, and the times they matched
+// in the parsers of the package. The counts aren't synchronized between parsers running concurrently.
+var {{.StructName}}Coverage = [...]struct {
+	rule		string
+	begin, end	int
+	count		int
+}{ {{range .Coverage}}{ {{printf "%q" .Rule}}, {{.Begin}}, {{.End}}, 0 },
+	{{end}} }
+
+// Write{{.StructName}}Coverage writes the times the rules and the alternatives of the grammar matched
+// so far, in the form read by peg -coverreport.
+func Write{{.StructName}}Coverage(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "peg coverage %v\n", {{printf "%q" .Grammar}}); err != nil {
+		return err
+	}
+	for _, c := range {{.StructName}}Coverage {
+		kind := "alternative"
+		if c.rule != "" {
+			kind = "rule " + c.rule
+		}
+		if _, err := fmt.Fprintf(w, "%v %v %v %v\n", kind, c.begin, c.end, c.count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+{{end}}
+
+{{if or .HasActions .HasValues}}
+// The tokens hold rune offsets while the actions index the buffer in bytes, so this translates
+// a rune offset into a byte offset when the text isn't made of single byte runes.
+func byteOffset(text string) func(offset int32) int {
+	if len(text) == utf8.RuneCountInString(text) {
+		return func(offset int32) int { return int(offset) }
+	}
+	offsets := make([]int, 0, len(text) + 1)
+	for i := range text {
+		offsets = append(offsets, i)
+	}
+	offsets = append(offsets, len(text))
+	return func(offset int32) int { return offsets[offset] }
+}
+{{end}}
+
 {{if .HasActions}}
 func (p *{{.StructName}}) Execute() {
-	buffer, begin, end := p.Buffer, 0, 0
+	buffer, begin, end, at := p.Buffer, 0, 0, byteOffset(p.Buffer)
+	/* the actions may only use labels */
+	_, _, _ = buffer, begin, end
+	{{if .LabelRules}}
+	/* labeled captures stay visible until the token of the rule containing them */
+	captures := make([]token32, 0, 8)
+	text := func(label Rule) string {
+		for i := len(captures) - 1; i >= 0; i-- {
+			if capture := captures[i]; capture.Rule == label {
+				return buffer[at(capture.begin):at(capture.end)]
+			}
+		}
+		return ""
+	}
+	{{end}}
 	for token := range p.TokenTree.Tokens() {
 		switch (token.Rule) {
 		case RulePegText:
-			begin, end = int(token.begin), int(token.end)
+			begin, end = at(token.begin), at(token.end)
+		{{if .LabelRules}}case {{range $i, $label := .LabelRules}}{{if $i}}, {{end}}Rule{{$label}}{{end}}:
+			captures = append(captures, token)
+		{{end}}
 		{{range .Actions}}case RuleAction{{.GetId}}:
-			{{.String}}
+			{{if .Labels}}func({{range $i, $label := .Labels}}{{if $i}}, {{end}}{{$label}}{{end}} string) {
+				{{.String}}
+			}({{range $i, $label := .Labels}}{{if $i}}, {{end}}text(RuleLabel_{{$label}}){{end}}){{else}}{{.String}}{{end}}
+		{{end}}
+		{{if .LabelRules}}default:
+			for length := len(captures); length > 0 && captures[length - 1].next > token.next; length-- {
+				captures = captures[:length - 1]
+			}
+		{{end}}
+		}
+	}
+}
+{{end}}
+
+{{if .HasValues}}
+type value struct {
+	Rule
+	begin, end, depth, count int
+	label bool
+	value interface{}
+}
+
+// Tokens arrive children first, so the values of a rule's children are on top of the stack when its token is
+// reached. A label leaves a marker after the values of its expression.
+func (p *{{.StructName}}) evaluate() (interface{}, error) {
+	buffer, values, tokens, at := p.Buffer, make([]value, 0, 64), p.TokenTree.Tokens(), byteOffset(p.Buffer)
+	for token := range tokens {
+		begin, end, depth := at(token.begin), at(token.end), int(token.next)
+		top := len(values)
+		for top > 0 && values[top - 1].depth > depth {
+			top--
+		}
+		children := values[top:]
+		switch (token.Rule) {
+		{{range .Values}}case Rule{{.Rule}}:
+			{{if .Labels}}
+			{{range .Labels}}var label_{{.Name}} {{.Type}}
+			{{end}}
+			for i := range children {
+				switch child := children[i]; child.Rule {
+				{{range .Labels}}case RuleLabel_{{.Name}}:
+					{{if .Repeated}}for _, element := range children[i - child.count:i] {
+						e, _ := element.value.({{.Element}})
+						label_{{.Name}} = append(label_{{.Name}}, e)
+					}{{else if .Typed}}if child.count > 0 {
+						label_{{.Name}}, _ = children[i - 1].value.({{.Type}})
+					}{{else}}label_{{.Name}} = buffer[child.begin:child.end]{{end}}
+				{{end}}
+				}
+			}
+			{{end}}
+			result, err := func(text string{{range .Labels}}, {{.Name}} {{.Type}}{{end}}) ({{.Type}}, error) {
+				{{.Action}}
+			}(buffer[begin:end]{{range .Labels}}, label_{{.Name}}{{end}})
+			if err != nil {
+				for _ = range tokens {
+				}
+				return nil, err
+			}
+			values = append(values[:top], value{Rule: token.Rule, begin: begin, end: end, depth: depth, value: result})
 		{{end}}
+		{{if .LabelRules}}case {{range $i, $label := .LabelRules}}{{if $i}}, {{end}}Rule{{$label}}{{end}}:
+			for i := range children {
+				children[i].depth = depth
+			}
+			values = append(values, value{Rule: token.Rule, begin: begin, end: end, depth: depth, count: len(children), label: true})
+		{{end}}
+		default:
+			/* untyped rules pass their children's values up, hiding their labels */
+			for _, child := range children {
+				if !child.label {
+					child.depth = depth
+					values[top] = child
+					top++
+				}
+			}
+			values = values[:top]
 		}
 	}
+
+	if length := len(values); length > 0 {
+		return values[length - 1].value, nil
+	}
+	return nil, nil
 }
 {{end}}
 
+/* ParseRule parses the buffer starting with the given rule, which must be used or public. */
+func (p *{{.StructName}}) ParseRule(rule Rule) {{if .HasValues}}(interface{}, error){{else}}error{{end}} {
+	if rule == RuleUnknown || int(rule) >= len(p.rules) || p.rules[rule] == nil {
+		name := fmt.Sprint(int(rule))
+		if int(rule) < len(Rul3s) {
+			name = Rul3s[rule]
+		}
+		return {{if .HasValues}}nil, {{end}}fmt.Errorf("rule %v isn't an entry point", name)
+	}
+	return p.Parse(int(rule))
+}
+
+/* ParseString parses the input starting with the given rule. */
+func (p *{{.StructName}}) ParseString(rule Rule, input string) {{if .HasValues}}(interface{}, error){{else}}error{{end}} {
+	p.Buffer = input
+	p.Init()
+	return p.ParseRule(rule)
+}
+
+// Match matches the rule at the rune offset of the input, without requiring the rest of the
+// input to match, and returns the rune offset where the match ended. The rune buffer is only
+// rebuilt when the input changes, so a rule can be matched repeatedly along the same input.
+func (p *{{.StructName}}) Match(rule Rule, input string, offset int) (end int, ok bool) {
+	if p.match == nil || input != p.Buffer {
+		p.Buffer = input
+		p.Init()
+	}
+	if rule == RuleUnknown || int(rule) >= len(p.rules) || p.rules[rule] == nil ||
+		offset < 0 || offset >= len(p.buffer) {
+		return 0, false
+	}
+	return p.match(rule, offset)
+}
+
 func (p *{{.StructName}}) Init() {
 	p.buffer = []rune(p.Buffer)
 	if len(p.buffer) == 0 || p.buffer[len(p.buffer) - 1] != END_SYMBOL {
@@ -401,23 +649,50 @@ func (p *{{.StructName}}) Init() {
 
 	var tree TokenTree = &tokens16{tree: make([]token16, math.MaxInt16)}
 	position, depth, tokenIndex, buffer, rules := 0, 0, 0, p.buffer, p.rules
+	{{if .Examine}}
+	/* the furthest position read by the parser */
+	examined := 0
+	{{end}}
+	{{if .Incremental}}
+	/* extents[i] is the furthest position examined before token i was added */
+	start, parsed, extents := 1, 0, make([]int, 0, math.MaxInt16)
+	var previous []token32
+	var previousExtents []int
+	var reusable map[[2]int]reusableToken
+	{{end}}
 
-	p.Parse = func(rule ...int) error {
+	p.Parse = func(rule ...int) {{if .HasValues}}(interface{}, error){{else}}error{{end}} {
 		r := 1
 		if len(rule) > 0 {
 			r = rule[0]
 		}
+		{{if .Incremental}}start, parsed = r, 0{{end}}
 		matches := p.rules[r]()
 		p.TokenTree = tree
 		if matches {
 			p.TokenTree.trim(tokenIndex)
-			return nil
+			{{if .Incremental}}parsed = tokenIndex{{end}}
+			{{if .HasValues}}return p.evaluate(){{else}}return nil{{end}}
 		}
-		return &parseError{p}
+		return {{if .HasValues}}nil, {{end}}&parseError{p}
 	}
 
 	p.Reset = func() {
 		position, tokenIndex, depth = 0, 0, 0
+		{{if .Examine}}examined = 0{{end}}
+	}
+
+	p.match = func(rule Rule, offset int) (int, bool) {
+		position, tokenIndex, depth = offset, 0, 0
+		{{if .Examine}}examined = offset{{end}}
+		{{if .Incremental}}parsed = 0{{end}}
+		matches := rules[rule]()
+		p.TokenTree = tree
+		if !matches {
+			return 0, false
+		}
+		p.TokenTree.trim(tokenIndex)
+		return position, true
 	}
 
 	add := func(rule Rule, begin int) {
@@ -425,9 +700,152 @@ func (p *{{.StructName}}) Init() {
 			tree = t
 		}
 		tree.Add(rule, begin, position, depth, tokenIndex)
+		{{if .Examine}}
+		if position > examined {
+			examined = position
+		}
+		{{end}}
+		{{if .Incremental}}extents = append(extents[:tokenIndex], examined){{end}}
 		tokenIndex++
 	}
 
+	{{if .Incremental}}
+	/* The tokens of the previous parse memoize the rules that matched. A token can be
+	   reused when nothing it examined was edited, shifting it if it follows the edit. */
+	p.Reparse = func(edit Edit) {{if .HasValues}}(interface{}, error){{else}}error{{end}} {
+		if edit.Begin < 0 || edit.Begin > edit.End || edit.End >= len(buffer) {
+			return {{if .HasValues}}nil, {{end}}fmt.Errorf("invalid edit %v-%v", edit.Begin, edit.End)
+		}
+
+		previous, previousExtents = make([]token32, 0, parsed), make([]int, parsed)
+		copy(previousExtents, extents[:parsed])
+		if parsed > 0 {
+			for token := range tree.Tokens() {
+				previous = append(previous, token)
+			}
+		}
+
+		text := []rune(edit.Text)
+		delta := len(text) - (edit.End - edit.Begin)
+		reusable = make(map[[2]int]reusableToken, len(previous))
+		for i, token := range previous {
+			begin, shift := int(token.begin), 0
+			if previousExtents[i] >= edit.Begin {
+				if begin < edit.End {
+					continue
+				}
+				shift = delta
+			}
+			reusable[[2]int{int(token.Rule), begin + shift}] = reusableToken{index: i, shift: shift}
+		}
+
+		edited := make([]rune, 0, len(buffer) + delta)
+		edited = append(append(append(edited, buffer[:edit.Begin]...), text...), buffer[edit.End:]...)
+		p.buffer, p.Buffer, buffer = edited, string(edited[:len(edited) - 1]), edited
+		position, tokenIndex, depth, examined = 0, 0, 0, 0
+		defer func() {
+			previous, previousExtents, reusable = nil, nil, nil
+		}()
+		return p.Parse(start)
+	}
+
+	reuse := func(rule Rule) bool {
+		r, ok := reusable[[2]int{int(rule), position}]
+		if !ok {
+			return false
+		}
+
+		last := previous[r.index]
+		first := r.index
+		for first > 0 && previous[first - 1].next > last.next {
+			first--
+		}
+		offset := depth - int(last.next)
+		for i, token := range previous[first:r.index + 1] {
+			if t := tree.Expand(tokenIndex); t != nil {
+				tree = t
+			}
+			tree.Add(token.Rule, int(token.begin) + r.shift, int(token.end) + r.shift, int(token.next) + offset, tokenIndex)
+			extents = append(extents[:tokenIndex], previousExtents[first + i] + r.shift)
+			tokenIndex++
+		}
+
+		position = int(last.end) + r.shift
+		if extent := previousExtents[r.index] + r.shift; extent > examined {
+			examined = extent
+		}
+		return true
+	}
+	{{end}}
+
+	{{if .Stream}}
+	/* Matches records of the start rule one at a time, reading more input when a match examined
+	   the end of what has been read so far. Matched records are executed and discarded. */
+	p.ParseReader = func(reader io.Reader) error {
+		/* the records are parsed from the window of runes read, starting at start, which moves
+		   forward past each record and back to 0 when the window is refilled */
+		chunk, partial, runes, window, start, offset, eof := make([]byte, 1 << 16), 0, []rune(nil), []rune(nil), 0, 0, false
+		for {
+			if !eof {
+				n, err := reader.Read(chunk[partial:])
+				if err == io.EOF {
+					eof = true
+				} else if err != nil {
+					return err
+				}
+
+				n += partial
+				complete := n
+				if !eof {
+					for i := n - 1; i >= 0 && i > n - utf8.UTFMax; i-- {
+						if utf8.RuneStart(chunk[i]) {
+							if !utf8.FullRune(chunk[i:n]) {
+								complete = i
+							}
+							break
+						}
+					}
+				}
+				offset += start
+				runes = append(runes[:copy(runes, runes[start:])], []rune(string(chunk[:complete]))...)
+				window, start = append(runes, END_SYMBOL), 0
+				partial = copy(chunk, chunk[complete:n])
+			}
+
+			for {
+				buffer = window[start:]
+				p.buffer = buffer
+				position, tokenIndex, depth, examined = 0, 0, 0, 0
+				matches := rules[Rule{{.StreamRule}}]()
+				p.TokenTree = tree
+				if !eof && examined >= len(buffer) - 1 {
+					break
+				}
+
+				if matches && position > 0 {
+					tree.trim(tokenIndex)
+					{{if .HasActions}}
+					p.Buffer = string(buffer[:position])
+					p.Execute()
+					{{end}}
+					start += position
+					continue
+				}
+
+				{{if .StreamEnd}}
+				if rest := window[start:len(window) - 1]; len(rest) > 0 {
+					if examined >= len(rest) {
+						examined = len(rest) - 1
+					}
+					return fmt.Errorf("parse error at rune %v near %q", offset+start+examined, string(rest[examined:]))
+				}
+				{{end}}
+				return nil
+			}
+		}
+	}
+	{{end}}
+
 	{{if .HasDot}}
 	matchDot := func() bool {
 		if buffer[position] != END_SYMBOL {
@@ -453,6 +871,30 @@ func (p *{{.StructName}}) Init() {
 		i := position
 		for _, c := range s {
 			if buffer[i] != c {
+				{{if .Examine}}
+				if i > examined {
+					examined = i
+				}
+				{{end}}
+				return false
+			}
+			i++
+		}
+		position = i
+		return true
+	}
+	{{end}}
+
+	{{if .HasBackReference}}
+	matchCapture := func(capture [2]int) bool {
+		i := position
+		for _, c := range buffer[capture[0]:capture[1]] {
+			if buffer[i] != c {
+				{{if .Examine}}
+				if i > examined {
+					examined = i
+				}
+				{{end}}
 				return false
 			}
 			i++
@@ -472,6 +914,46 @@ func (p *{{.StructName}}) Init() {
 	}*/
 	{{end}}
 
+	{{if .Profiling}}
+	/* the time spent in the rules called by each rule being matched */
+	var called []time.Duration
+	profile := func(rule Rule) time.Time {
+		p.Profile[rule].Rule = rule
+		p.Profile[rule].Calls++
+		called = append(called, 0)
+		return time.Now()
+	}
+	profiled := func(rule Rule, start time.Time, matched bool) {
+		elapsed, last, profile := time.Since(start), len(called) - 1, &p.Profile[rule]
+		if matched {
+			profile.Matches++
+		} else {
+			profile.Failures++
+		}
+		profile.Time += elapsed
+		profile.Self += elapsed - called[last]
+		if called = called[:last]; last > 0 {
+			called[last - 1] += elapsed
+		}
+	}
+	{{end}}
+
+	{{if .Tracing}}
+	/* Prints the call of a rule to p.Trace, indented by the depth of its token, returning
+	   the function printing whether it matched. */
+	trace := func(rule Rule) func(matched bool) {
+		begin, indent := position, strings.Repeat(" ", depth)
+		fmt.Fprintf(p.Trace, "%v%v at %v\n", indent, Rul3s[rule], begin)
+		return func(matched bool) {
+			if matched {
+				fmt.Fprintf(p.Trace, "%v%v matched %v\n", indent, Rul3s[rule], strconv.Quote(string(buffer[begin:position])))
+			} else {
+				fmt.Fprintf(p.Trace, "%v%v failed\n", indent, Rul3s[rule])
+			}
+		}
+	}
+	{{end}}
+
 	rules = [...]func() bool {
 		nil,`
 
@@ -501,6 +983,13 @@ const (
 	TypePeg
 	TypePush
 	TypeImplicitPush
+	TypeLabel
+	TypeValue
+	TypeDirective
+	TypePrecedence
+	TypeOperators
+	TypeBackReference
+	TypeRepeat
 	TypeNil
 	TypeLast
 )
@@ -529,6 +1018,13 @@ var TypeMap = [...]string{
 	"TypePeg",
 	"TypePush",
 	"TypeImplicitPush",
+	"TypeLabel",
+	"TypeValue",
+	"TypeDirective",
+	"TypePrecedence",
+	"TypeOperators",
+	"TypeBackReference",
+	"TypeRepeat",
 	"TypeNil",
 	"TypeLast"}
 
@@ -571,6 +1067,10 @@ type node struct {
 
 	/* use hash table here instead of Copy? */
 	next *node
+
+	/* the runes of the grammar the node was written as, and its counter plus one under -cover */
+	begin, end int
+	cover      int
 }
 
 func (n *node) String() string {
@@ -660,7 +1160,8 @@ func (n *node) Len() (c int) {
 }
 
 func (n *node) Copy() *node {
-	return &node{Type: n.Type, string: n.string, id: n.id, front: n.front, back: n.back, length: n.length}
+	return &node{Type: n.Type, string: n.string, id: n.id, front: n.front, back: n.back, length: n.length,
+		begin: n.begin, end: n.end, cover: n.cover}
 }
 
 func (n *node) Slice() []*node {
@@ -671,10 +1172,41 @@ func (n *node) Slice() []*node {
 	return s
 }
 
+/* An action and the labels of its rule, which are bound to the captured text. */
+type ruleAction struct {
+	Node
+	Labels []string
+}
+
+/* A rule, or an alternative when Rule is empty, counted by a parser generated with -cover. */
+type coverPoint struct {
+	Rule       string
+	Begin, End int
+}
+
+/* A typed rule and the labels bound as arguments of its value action. */
+type ruleValue struct {
+	Rule, Type, Action string
+	Labels             []ruleLabel
+}
+
+/* A label is typed when it names a typed rule, otherwise it binds the matched text. */
+type ruleLabel struct {
+	Name, Type, Element string
+	Typed, Repeated     bool
+}
+
 /* A tree data structure into which a PEG can be parsed. */
 type Tree struct {
 	Rules      map[string]Node
 	rulesCount map[string]uint
+	valueTypes map[string]string
+	public     map[string]bool
+	lexer      map[string]bool
+	suppressed map[string]bool
+	skip       string
+	prepared   bool
+	counts     [TypeLast]uint
 	node
 	inline, _switch bool
 
@@ -687,20 +1219,51 @@ type Tree struct {
 	RulesCount      int
 	Bits            int
 	HasActions      bool
-	Actions         []Node
+	Actions         []ruleAction
 	HasCommit       bool
 	HasDot          bool
 	HasCharacter    bool
 	HasString       bool
+	HasBackReference bool
 	HasRange        bool
+	HasValues       bool
+	HasPrecedence   bool
+	Values          []ruleValue
+	LabelRules      []string
+	Incremental     bool
+	Stream          bool
+	StreamRule      string
+	StreamEnd       bool
+	Examine         bool
+	Tracing         bool
+	Profiling       bool
+	Covering        bool
+	Coverage        []coverPoint
+	Grammar         string
+	/* the command, version of peg and sha256 of the grammar recorded at the top of the parser */
+	Command, Version, Hash string
+}
+
+/* The options of the parser generated from a tree. */
+type Options struct {
+	Inline, Switch, Incremental, Stream, Tracing, Profiling, Covering bool
 }
 
-func New(inline, _switch bool) *Tree {
+func New(options Options) *Tree {
 	return &Tree{Rules: make(map[string]Node),
-		Sizes:      [2]int{16, 32},
-		rulesCount: make(map[string]uint),
-		inline:     inline,
-		_switch:    _switch}
+		Sizes:       [2]int{16, 32},
+		rulesCount:  make(map[string]uint),
+		valueTypes:  make(map[string]string),
+		public:      make(map[string]bool),
+		lexer:       make(map[string]bool),
+		suppressed:  make(map[string]bool),
+		inline:      options.Inline,
+		_switch:     options.Switch,
+		Incremental: options.Incremental,
+		Stream:      options.Stream,
+		Tracing:     options.Tracing,
+		Profiling:   options.Profiling,
+		Covering:    options.Covering}
 }
 
 func (t *Tree) AddRule(name string) {
@@ -708,6 +1271,17 @@ func (t *Tree) AddRule(name string) {
 	t.RulesCount++
 }
 
+/* Records where the rule or the alternative just added was written, for -cover. */
+func (t *Tree) Locate(begin, end int) {
+	front := t.Front()
+	front.begin, front.end = begin, end
+}
+
+/* The rule being defined adds no token of its own. */
+func (t *Tree) AddSuppressed() {
+	t.suppressed[t.Front().String()] = true
+}
+
 func (t *Tree) AddExpression() {
 	expression := t.PopFront()
 	rule := t.PopFront()
@@ -715,6 +1289,52 @@ func (t *Tree) AddExpression() {
 	t.PushBack(rule)
 }
 
+/* The result type and value action are attached to the most recently added rule. */
+func (t *Tree) AddValue(text string) {
+	rule := t.back
+	rule.PushBack(&node{Type: TypeValue, string: strings.TrimSpace(text)})
+}
+
+func (t *Tree) AddValueAction(text string) {
+	value := t.back.back
+	value.PushBack(&node{Type: TypeAction, string: text})
+}
+
+/* An operator precedence level follows the operand of a rule, binding tighter than the levels before it. */
+func (t *Tree) AddOperators(associativity string) {
+	t.PushFront(&node{Type: TypeOperators, string: associativity})
+}
+
+func (t *Tree) AddPrecedence() {
+	operators, level, operand := t.PopFront(), t.PopFront(), t.PopFront()
+	if operators.GetType() == TypeAlternate {
+		for _, operator := range operators.Slice() {
+			operator.next = nil
+			level.PushBack(operator)
+		}
+	} else {
+		level.PushBack(operators)
+	}
+	if operand.GetType() != TypePrecedence {
+		precedence := &node{Type: TypePrecedence}
+		precedence.PushBack(operand)
+		operand = precedence
+	}
+	operand.PushBack(level)
+	t.PushFront(operand)
+}
+
+func (t *Tree) AddLabel(text string) {
+	t.PushFront(&node{Type: TypeLabel, string: text})
+}
+
+func (t *Tree) AddLabeled() {
+	expression := t.PopFront()
+	label := t.PopFront()
+	label.PushBack(expression)
+	t.PushFront(label)
+}
+
 func (t *Tree) AddName(text string) {
 	t.PushFront(&node{Type: TypeName, string: text})
 }
@@ -730,12 +1350,20 @@ func (t *Tree) AddDoubleCharacter(text string) {
 }
 func (t *Tree) AddOctalCharacter(text string) {
 	octal, _ := strconv.ParseInt(text, 8, 8)
-	t.PushFront(&node{Type: TypeCharacter, string: string(octal)})
+	t.PushFront(&node{Type: TypeCharacter, string: string(rune(octal))})
 }
 func (t *Tree) AddPredicate(text string) { t.PushFront(&node{Type: TypePredicate, string: text}) }
 func (t *Tree) AddNil()                  { t.PushFront(&node{Type: TypeNil, string: "<nil>"}) }
+func (t *Tree) AddCommit()               { t.PushFront(&node{Type: TypeCommit, string: "^"}) }
+func (t *Tree) AddBackReference(text string) {
+	t.PushFront(&node{Type: TypeBackReference, string: text})
+}
 func (t *Tree) AddAction(text string)    { t.PushFront(&node{Type: TypeAction, string: text}) }
 func (t *Tree) AddPackage(text string)   { t.PushBack(&node{Type: TypePackage, string: text}) }
+func (t *Tree) AddDirective(text string) { t.PushBack(&node{Type: TypeDirective, string: text}) }
+func (t *Tree) AddDirectiveArgument(text string) {
+	t.back.PushBack(&node{Type: TypeName, string: text})
+}
 func (t *Tree) AddState(text string) {
 	peg := t.PopFront()
 	peg.PushBack(&node{Type: TypeState, string: text})
@@ -784,6 +1412,28 @@ func (t *Tree) AddQuery()   { t.addFix(TypeQuery) }
 func (t *Tree) AddStar()    { t.addFix(TypeStar) }
 func (t *Tree) AddPlus()    { t.addFix(TypePlus) }
 func (t *Tree) AddPush()    { t.addFix(TypePush) }
+func (t *Tree) AddRepetition(text string) {
+	t.addFix(TypeRepeat)
+	t.Front().SetString(text)
+	if min, max := repetitionBounds(t.Front()); max >= 0 && max < min {
+		fmt.Fprintf(os.Stderr, "repetition {%v} can't match\n", text)
+	}
+}
+
+/* The bounds of a repetition written as {n}, {n,} or {n,m}, max is -1 when unbounded. */
+func repetitionBounds(n Node) (min, max int) {
+	bounds := strings.SplitN(n.String(), ",", 2)
+	min, _ = strconv.Atoi(bounds[0])
+	switch {
+	case len(bounds) == 1:
+		max = min
+	case bounds[1] == "":
+		max = -1
+	default:
+		max, _ = strconv.Atoi(bounds[1])
+	}
+	return
+}
 
 func (t *Tree) AddPeg(text string) { t.PushFront(&node{Type: TypePeg, string: text}) }
 
@@ -797,6 +1447,59 @@ func join(tasks []func()) {
 	}
 }
 
+/* Visits the nodes of an expression without following references to other rules. */
+func walk(n Node, visit func(n Node)) {
+	visit(n)
+	switch n.GetType() {
+	case TypeLabel, TypePush, TypeImplicitPush:
+		walk(n.Front(), visit)
+	case TypeAlternate, TypeUnorderedAlternate, TypeSequence, TypePeekFor, TypePeekNot,
+		TypeQuery, TypeStar, TypePlus, TypeRepeat, TypePrecedence, TypeOperators:
+		for _, element := range n.Slice() {
+			walk(element, visit)
+		}
+	}
+}
+
+/* Visits the labels of an expression without following references to other rules. */
+func walkLabels(n Node, visit func(label Node)) {
+	walk(n, func(n Node) {
+		if n.GetType() == TypeLabel {
+			visit(n)
+		}
+	})
+}
+
+/* The start rule of a streaming parser repeats a rule, optionally followed by !. */
+func streamRule(n Node) (name string, end bool) {
+	if n.GetType() == TypeSequence {
+		elements := n.Slice()
+		if len(elements) != 2 || elements[1].GetType() != TypePeekNot || elements[1].Front().GetType() != TypeDot {
+			return "", false
+		}
+		n, end = elements[0], true
+	}
+	if n.GetType() == TypeStar && n.Front().GetType() == TypeName {
+		return n.Front().String(), end
+	}
+	return "", false
+}
+
+/* Reports whether a commit within the expression applies to the choice enclosing it. */
+func hasCommit(n Node) bool {
+	switch n.GetType() {
+	case TypeCommit:
+		return true
+	case TypeSequence, TypePush, TypeImplicitPush, TypeLabel:
+		for _, element := range n.Slice() {
+			if hasCommit(element) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func escape(c string) string {
 	switch c {
 	case "'":
@@ -810,13 +1513,27 @@ func escape(c string) string {
 	return ""
 }
 
-func (t *Tree) Compile(file string) {
+/* Rules referenced once are compiled in place, unless they produce a value, are streamed or are public. */
+func (t *Tree) inlined(name string) bool {
+	if _, typed := t.valueTypes[name]; typed || name == t.StreamRule || t.public[name] {
+		return false
+	}
+	return t.inline && t.rulesCount[name] == 1
+}
+
+/* Links the rules of the tree and analyzes them, once, for compiling or interpreting them. */
+func (t *Tree) prepare() [TypeLast]uint {
+	if t.prepared {
+		return t.counts
+	}
+	t.prepared = true
 	t.EndSymbol = '\u0004'
 	t.RulesCount++
 
 	counts := [TypeLast]uint{}
 	{
 		var rule *node
+		var labels []string
 		var link func(node Node)
 		link = func(n Node) {
 			nodeType := n.GetType()
@@ -826,7 +1543,7 @@ func (t *Tree) Compile(file string) {
 			case TypeAction:
 				n.SetId(int(id))
 				copy, name := n.Copy(), fmt.Sprintf("Action%v", id)
-				t.Actions = append(t.Actions, copy)
+				t.Actions = append(t.Actions, ruleAction{Node: copy, Labels: labels})
 				n.Init()
 				n.SetType(TypeName)
 				n.SetString(name)
@@ -856,8 +1573,11 @@ func (t *Tree) Compile(file string) {
 					t.Rules[name] = emptyRule
 					t.RuleNames = append(t.RuleNames, emptyRule)
 				}
-			case TypePush:
+			case TypePush, TypeLabel:
 				copy, name := rule.Copy(), "PegText"
+				if nodeType == TypeLabel {
+					name = "Label_" + n.String()
+				}
 				copy.SetString(name)
 				if _, ok := t.Rules[name]; !ok {
 					emptyRule := &node{Type: TypeRule, string: name, id: t.RulesCount}
@@ -867,13 +1587,19 @@ func (t *Tree) Compile(file string) {
 
 					t.Rules[name] = emptyRule
 					t.RuleNames = append(t.RuleNames, emptyRule)
+					if nodeType == TypeLabel {
+						t.LabelRules = append(t.LabelRules, name)
+					}
 				}
 				n.PushBack(copy)
 				fallthrough
 			case TypeImplicitPush:
 				link(n.Front())
-			case TypeRule, TypeAlternate, TypeUnorderedAlternate, TypeSequence,
-				TypePeekFor, TypePeekNot, TypeQuery, TypeStar, TypePlus:
+			case TypePrecedence:
+				n.SetString(rule.String())
+				fallthrough
+			case TypeRule, TypeAlternate, TypeUnorderedAlternate, TypeSequence, TypePeekFor,
+				TypePeekNot, TypeQuery, TypeStar, TypePlus, TypeRepeat, TypeOperators:
 				for _, node := range n.Slice() {
 					link(node)
 				}
@@ -887,9 +1613,35 @@ func (t *Tree) Compile(file string) {
 			case TypePeg:
 				t.StructName = node.String()
 				t.StructVariables = node.Front().String()
+			case TypeDirective:
+				switch node.String() {
+				case "public":
+					for _, name := range node.Slice() {
+						t.public[name.String()] = true
+					}
+				case "lexer":
+					for _, name := range node.Slice() {
+						t.lexer[name.String()] = true
+					}
+				case "skip":
+					if node.Len() != 1 {
+						fmt.Fprintf(os.Stderr, "directive '%%%v' takes one rule\n", node)
+						break
+					}
+					t.skip = node.Front().String()
+				default:
+					fmt.Fprintf(os.Stderr, "unknown directive '%%%v'\n", node)
+				}
 			case TypeRule:
 				if _, ok := t.Rules[node.String()]; !ok {
 					expression := node.Front()
+					if t.Stream && len(t.RuleNames) == 0 {
+						t.StreamRule, t.StreamEnd = streamRule(expression)
+						if t.StreamRule == "" {
+							fmt.Fprintf(os.Stderr, "rule '%v' isn't a repetition of a rule, streaming disabled\n", node)
+							t.Stream = false
+						}
+					}
 					copy := expression.Copy()
 					expression.Init()
 					expression.SetType(TypeImplicitPush)
@@ -901,13 +1653,227 @@ func (t *Tree) Compile(file string) {
 				}
 			}
 		}
+		for _, node := range t.Slice() {
+			if node.GetType() != TypeDirective {
+				continue
+			}
+			for _, name := range node.Slice() {
+				if _, ok := t.Rules[name.String()]; !ok {
+					fmt.Fprintf(os.Stderr, "directive '%%%v' names undefined rule '%v'\n", node, name)
+				}
+			}
+		}
+		/* skip pass, the skip rule is matched before the tokens of the syntactic rules */
+		if _, ok := t.Rules[t.skip]; ok {
+			/* the lexer rules, the skip rule and the rules they use are lexical */
+			lexical := make(map[string]bool)
+			var lex func(n Node)
+			lex = func(n Node) {
+				if n.GetType() == TypeName {
+					if rule, ok := t.Rules[n.String()]; ok && !lexical[n.String()] {
+						lexical[n.String()] = true
+						lex(rule.Front().Front())
+					}
+					return
+				}
+				for _, element := range n.Slice() {
+					lex(element)
+				}
+			}
+			lex(&node{Type: TypeName, string: t.skip})
+			for name := range t.lexer {
+				lex(&node{Type: TypeName, string: name})
+			}
+
+			/* the tokens are the expressions made only of literals, classes, dots and predicates on them,
+			   the back references and the references to lexical rules */
+			var terminal func(n Node) bool
+			terminal = func(n Node) bool {
+				switch n.GetType() {
+				case TypeDot, TypeCharacter, TypeRange, TypeString:
+					return true
+				case TypeAlternate, TypeUnorderedAlternate, TypeSequence, TypePeekFor, TypePeekNot,
+					TypeQuery, TypeStar, TypePlus, TypeRepeat, TypePush, TypeLabel:
+					for _, element := range n.Slice() {
+						if !terminal(element) {
+							return false
+						}
+					}
+					return n.Front() != nil
+				}
+				return false
+			}
+			token := func(n Node) bool {
+				switch n.GetType() {
+				case TypeBackReference:
+					return true
+				case TypeName:
+					return lexical[n.String()]
+				}
+				return terminal(n)
+			}
+			/* puts the skip rule before each token of the expression */
+			var skip func(n *node) *node
+			skip = func(n *node) *node {
+				if token(n) {
+					sequence := &node{Type: TypeSequence, begin: n.begin, end: n.end}
+					sequence.PushBack(&node{Type: TypeName, string: t.skip})
+					sequence.PushBack(n)
+					return sequence
+				}
+				elements := n.Slice()
+				n.Init()
+				for i := 0; i < len(elements); i++ {
+					element := elements[i]
+					element.next = nil
+					/* consecutive terminals of a sequence, such as the characters of a literal, are one token */
+					if n.Type == TypeSequence && terminal(element) && i+1 < len(elements) && terminal(elements[i+1]) {
+						element = &node{Type: TypeSequence, begin: element.begin}
+						for ; i < len(elements) && terminal(elements[i]); i++ {
+							elements[i].next = nil
+							element.PushBack(elements[i])
+							element.end = elements[i].end
+						}
+						i--
+					}
+					n.PushBack(skip(element))
+				}
+				return n
+			}
+			for _, node := range t.Slice() {
+				if node.GetType() != TypeRule || lexical[node.String()] || t.Rules[node.String()] != node {
+					continue
+				}
+				implicitPush := node.Front()
+				elements := implicitPush.Slice()
+				implicitPush.Init()
+				elements[0].next, elements[1].next = nil, nil
+				implicitPush.PushBack(skip(elements[0]))
+				implicitPush.PushBack(elements[1])
+			}
+		}
 		/* second pass */
 		for _, node := range t.Slice() {
 			if node.GetType() == TypeRule {
-				rule = node
+				rule, labels = node, nil
+				walkLabels(node.Front(), func(n Node) {
+					for _, label := range labels {
+						if label == n.String() {
+							return
+						}
+					}
+					labels = append(labels, n.String())
+				})
 				link(node)
 			}
 		}
+		/* third pass, typed rules and the labels of their value actions */
+		for _, node := range t.Slice() {
+			if node.GetType() != TypeRule {
+				continue
+			}
+			labeled, labeledCaptures := make(map[string]bool), make(map[Node]bool)
+			walkLabels(node.Front(), func(n Node) {
+				labeled[n.String()], labeledCaptures[n.Front()] = true, true
+			})
+			walk(node.Front(), func(n Node) {
+				if n.GetType() == TypeBackReference && !labeled[n.String()] {
+					fmt.Fprintf(os.Stderr, "rule '%v' references undefined label '%v'\n", node, n)
+				}
+			})
+			/* begin and end hold a single capture, so only labeled captures can be nested */
+			nested, unlabeled := false, func(n Node) bool { return n.GetType() == TypePush && !labeledCaptures[n] }
+			walk(node.Front(), func(n Node) {
+				if unlabeled(n) {
+					walk(n.Front(), func(inner Node) {
+						nested = nested || unlabeled(inner)
+					})
+				}
+			})
+			if nested {
+				fmt.Fprintf(os.Stderr, "rule '%v' nests a capture in another, label one of them\n", node)
+			}
+			if value := node.Front().Next(); value != nil && value.GetType() == TypeValue {
+				t.valueTypes[node.String()] = value.String()
+				if t.suppressed[node.String()] {
+					fmt.Fprintf(os.Stderr, "rule '%v' produces a value, its token can't be suppressed\n", node)
+					delete(t.suppressed, node.String())
+				}
+			}
+		}
+		for _, node := range t.Slice() {
+			if node.GetType() != TypeRule {
+				continue
+			}
+			value := node.Front().Next()
+			if value == nil || value.GetType() != TypeValue {
+				continue
+			}
+			r := ruleValue{Rule: node.String(), Type: value.String()}
+			if action := value.Front(); action != nil {
+				r.Action = action.String()
+			}
+			walkLabels(node.Front(), func(n Node) {
+				label, target := ruleLabel{Name: n.String(), Type: "string"}, n.Front()
+				switch target.GetType() {
+				case TypeName:
+					if valueType, ok := t.valueTypes[target.String()]; ok {
+						label.Type, label.Typed = valueType, true
+					}
+				case TypeQuery, TypeStar, TypePlus, TypeRepeat:
+					if element := target.Front(); element.GetType() == TypeName {
+						if valueType, ok := t.valueTypes[element.String()]; ok {
+							label.Type, label.Typed = valueType, true
+							/* an optional element or one repeated at most once binds a single value */
+							single := target.GetType() == TypeQuery
+							if target.GetType() == TypeRepeat {
+								_, max := repetitionBounds(target)
+								single = max == 1
+							}
+							if !single {
+								label.Type, label.Element, label.Repeated = "[]"+valueType, valueType, true
+							}
+						}
+					}
+				}
+				for _, l := range r.Labels {
+					if l.Name == label.Name {
+						if l.Type != label.Type {
+							fmt.Fprintf(os.Stderr, "label '%v' has conflicting types in rule '%v'\n", label.Name, node)
+						}
+						return
+					}
+				}
+				r.Labels = append(r.Labels, label)
+			})
+			t.Values = append(t.Values, r)
+		}
+	}
+
+	/* the rules and alternatives written in the grammar are counted in the order they were defined */
+	if t.Covering {
+		for _, rule := range t.Slice() {
+			if rule.GetType() != TypeRule || rule.end == 0 || t.Rules[rule.String()] != rule {
+				continue
+			}
+			expression := rule.Front()
+			t.Coverage = append(t.Coverage, coverPoint{Rule: rule.String(), Begin: rule.begin, End: rule.end})
+			expression.cover = len(t.Coverage)
+			walk(expression, func(n Node) {
+				if n.GetType() != TypeAlternate && n.GetType() != TypeOperators {
+					return
+				}
+				for _, alternative := range n.Slice() {
+					if alternative.end > 0 {
+						t.Coverage = append(t.Coverage, coverPoint{Begin: alternative.begin, End: alternative.end})
+						alternative.cover = len(t.Coverage)
+					}
+				}
+			})
+		}
+		if len(t.Coverage) == 0 {
+			fmt.Fprintf(os.Stderr, "the grammar records no positions to cover\n")
+		}
 	}
 
 	join([]func(){
@@ -930,19 +1896,20 @@ func (t *Tree) Compile(file string) {
 					countRules(node.Front())
 				case TypeName:
 					countRules(t.Rules[node.String()])
-				case TypeImplicitPush, TypePush:
+				case TypeImplicitPush, TypePush, TypeLabel:
 					countRules(node.Front())
-				case TypeAlternate, TypeUnorderedAlternate, TypeSequence,
-					TypePeekFor, TypePeekNot, TypeQuery, TypeStar, TypePlus:
+				case TypeAlternate, TypeUnorderedAlternate, TypeSequence, TypePeekFor, TypePeekNot,
+					TypeQuery, TypeStar, TypePlus, TypeRepeat, TypePrecedence, TypeOperators:
 					for _, element := range node.Slice() {
 						countRules(element)
 					}
 				}
 			}
+			first := true
 			for _, node := range t.Slice() {
-				if node.GetType() == TypeRule {
+				if node.GetType() == TypeRule && (first || t.public[node.String()]) {
 					countRules(node)
-					break
+					first = false
 				}
 			}
 		},
@@ -976,8 +1943,13 @@ func (t *Tree) Compile(file string) {
 					}
 				case TypeName:
 					return checkRecursion(t.Rules[node.String()])
-				case TypePlus, TypePush, TypeImplicitPush:
+				case TypePlus, TypePush, TypeImplicitPush, TypeLabel, TypePrecedence:
 					return checkRecursion(node.Front())
+				case TypeRepeat:
+					if min, _ := repetitionBounds(node); min > 0 {
+						return checkRecursion(node.Front())
+					}
+					checkRecursion(node.Front())
 				case TypeCharacter, TypeString:
 					return len(node.String()) > 0
 				case TypeDot, TypeRange:
@@ -1069,6 +2041,8 @@ func (t *Tree) Compile(file string) {
 
 				c, unordered, ordered, max :=
 					0, &node{Type: TypeUnorderedAlternate}, &node{Type: TypeAlternate}, 0
+				/* the alternative without a first character, which has to be the default case */
+				var empty *node
 				for _, element := range n.Slice() {
 					if properties[c].intersects {
 						ordered.PushBack(element.Copy())
@@ -1076,7 +2050,7 @@ func (t *Tree) Compile(file string) {
 						class := &node{Type: TypeUnorderedAlternate}
 						for d := 0; d < 256; d++ {
 							if properties[c].s.has(uint8(d)) {
-								class.PushBack(&node{Type: TypeCharacter, string: string(d)})
+								class.PushBack(&node{Type: TypeCharacter, string: string(rune(d))})
 							}
 						}
 
@@ -1089,8 +2063,8 @@ func (t *Tree) Compile(file string) {
 						sequence.PushBack(predicate)
 						sequence.PushBack(element.Copy())
 
-						if element.GetType() == TypeNil {
-							unordered.PushBack(sequence)
+						if element.GetType() == TypeNil || length == 0 {
+							empty = sequence
 						} else if length > max {
 							unordered.PushBack(sequence)
 							max = length
@@ -1100,6 +2074,9 @@ func (t *Tree) Compile(file string) {
 					}
 					c++
 				}
+				if empty != nil {
+					unordered.PushBack(empty)
+				}
 				n.Init()
 				if ordered.Front() == nil {
 					n.SetType(TypeUnorderedAlternate)
@@ -1141,9 +2118,26 @@ func (t *Tree) Compile(file string) {
 				s = &set{}
 			case TypeQuery, TypeStar:
 				_, s = optimizeAlternates(n.Front())
-			case TypePlus, TypePush, TypeImplicitPush:
+			case TypePlus, TypePush, TypeImplicitPush, TypeLabel:
 				consumes, s = optimizeAlternates(n.Front())
-			case TypeAction, TypeNil:
+			case TypeRepeat:
+				if min, _ := repetitionBounds(n); min > 0 {
+					consumes, s = optimizeAlternates(n.Front())
+				} else {
+					_, s = optimizeAlternates(n.Front())
+				}
+			case TypePrecedence:
+				consumes, s = optimizeAlternates(n.Front())
+				for _, level := range n.Slice()[1:] {
+					for _, operator := range level.Slice() {
+						optimizeAlternates(operator)
+					}
+				}
+			case TypeBackReference:
+				/* the captured text can start with any character */
+				s = &set{}
+				s.complement()
+			case TypeAction, TypeCommit, TypeNil:
 				s = &set{}
 			}
 			return
@@ -1166,6 +2160,12 @@ func (t *Tree) Compile(file string) {
 			}
 		}
 	}
+	t.counts = counts
+	return counts
+}
+
+func (t *Tree) Compile(file string) {
+	counts := t.prepare()
 
 	out, error := os.OpenFile(file, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
 	if error != nil {
@@ -1194,8 +2194,31 @@ func (t *Tree) Compile(file string) {
 	}()
 
 	print := func(format string, a ...interface{}) { fmt.Fprintf(&buffer, format, a...) }
-	printSave := func(n uint) { print("\n   position%d, tokenIndex%d, depth%d := position, tokenIndex, depth", n, n, n) }
-	printRestore := func(n uint) { print("   position, tokenIndex, depth = position%d, tokenIndex%d, depth%d", n, n, n) }
+	/* the captures of the rule being compiled that are back referenced, saved and restored with the position */
+	var references []struct {
+		name string
+		id   uint
+	}
+	printSave := func(n uint) {
+		print("\n   position%d, tokenIndex%d, depth%d := position, tokenIndex, depth", n, n, n)
+		for _, reference := range references {
+			print("\n   capture%d_%d := capture%d", reference.id, n, reference.id)
+		}
+	}
+	/* the rule whose function is being compiled */
+	var profiled string
+	printRestore := func(n uint, backtrack bool) {
+		if backtrack && t.Profiling {
+			print("\n   if position > position%d {\np.Profile[Rule%v].Backtracks++\n}", n, profiled)
+		}
+		if t.Examine {
+			print("\n   if position > examined {\nexamined = position\n}")
+		}
+		print("\n   position, tokenIndex, depth = position%d, tokenIndex%d, depth%d", n, n, n)
+		for _, reference := range references {
+			print("\n   capture%d = capture%d_%d", reference.id, reference.id, n)
+		}
+	}
 	printTemplate := func(s string) {
 		if error := template.Must(template.New("peg").Parse(s)).Execute(&buffer, t); error != nil {
 			panic(error)
@@ -1205,9 +2228,14 @@ func (t *Tree) Compile(file string) {
 	t.HasActions = counts[TypeAction] > 0
 	t.HasCommit = counts[TypeCommit] > 0
 	t.HasDot = counts[TypeDot] > 0
+	t.HasPrecedence = counts[TypePrecedence] > 0
 	t.HasCharacter = counts[TypeCharacter] > 0
 	t.HasString = counts[TypeString] > 0
+	t.HasBackReference = counts[TypeBackReference] > 0
 	t.HasRange = counts[TypeRange] > 0
+	t.HasValues = len(t.Values) > 0
+	t.Examine = t.Incremental || t.Stream
+	t.Grammar = strings.TrimSuffix(filepath.Base(file), ".go")
 
 	var printRule func(n Node)
 	var compile func(expression Node, ko uint)
@@ -1225,6 +2253,44 @@ func (t *Tree) Compile(file string) {
 		print("\n   goto l%d", n)
 		labels[n] = true
 	}
+	/* the choice point a commit applies to */
+	var cut uint
+	cutting := false
+	/* the rules expanded in place within a lexer rule */
+	var lexing map[string]bool
+	/* declares the captures back referenced in the expression of a rule */
+	printCaptures := func(expression Node) {
+		references = nil
+		walk(expression, func(n Node) {
+			if n.GetType() != TypeBackReference {
+				return
+			}
+			for _, reference := range references {
+				if reference.name == n.String() {
+					return
+				}
+			}
+			references = append(references, struct {
+				name string
+				id   uint
+			}{n.String(), label})
+			print("\n   var capture%d [2]int", label)
+			label++
+		})
+	}
+	printCapture := func(n Node, begin string) {
+		for _, reference := range references {
+			if n.GetType() == TypeLabel && reference.name == n.String() {
+				print("\n   capture%d = [2]int{%v, position}", reference.id, begin)
+			}
+		}
+	}
+	printCommit := func(n uint) { print("\n   commit%d := false", n) }
+	printCommitted := func(n, ko uint) {
+		print("\n   if commit%d {", n)
+		printJump(ko)
+		print("}\n")
+	}
 	printRule = func(n Node) {
 		switch n.GetType() {
 		case TypeRule:
@@ -1244,13 +2310,13 @@ func (t *Tree) Compile(file string) {
 			lower := element
 			element = element.Next()
 			upper := element
-			print("[%v-%v]", lower, upper)
+			print("[%v-%v]", escape(lower.String()), escape(upper.String()))
 		case TypePredicate:
 			print("&{%v}", n)
 		case TypeAction:
 			print("{%v}", n)
 		case TypeCommit:
-			print("commit")
+			print("^")
 		case TypeAlternate:
 			print("(")
 			elements := n.Slice()
@@ -1297,11 +2363,45 @@ func (t *Tree) Compile(file string) {
 			print("<")
 			printRule(n.Front())
 			print(">")
+		case TypeLabel:
+			print("%v:", n)
+			printRule(n.Front())
+		case TypeBackReference:
+			print("$%v", n)
+		case TypeRepeat:
+			printRule(n.Front())
+			print("{%v}", n)
+		case TypePrecedence:
+			printRule(n.Front())
+			for _, level := range n.Slice()[1:] {
+				printRule(level)
+			}
+		case TypeOperators:
+			print(" %%%v ", n)
+			for i, operator := range n.Slice() {
+				if i > 0 {
+					print(" / ")
+				}
+				printRule(operator)
+			}
 		case TypeNil:
 		default:
 			fmt.Fprintf(os.Stderr, "illegal node type: %v\n", n.GetType())
 		}
 	}
+	/* counts the rule or the alternative once it matched */
+	printCover := func(n *node) {
+		if n.cover > 0 {
+			print("\n   %vCoverage[%d].count++", t.StructName, n.cover-1)
+		}
+	}
+	compileChoice := func(n *node, ko, choice uint, commits bool) {
+		saved, savedCutting := cut, cutting
+		cut, cutting = choice, commits
+		compile(n, ko)
+		cut, cutting = saved, savedCutting
+		printCover(n)
+	}
 	compile = func(n Node, ko uint) {
 		switch n.GetType() {
 		case TypeRule:
@@ -1315,8 +2415,25 @@ func (t *Tree) Compile(file string) {
 		case TypeName:
 			name := n.String()
 			rule := t.Rules[name]
-			if t.inline && t.rulesCount[name] == 1 {
-				compile(rule.Front(), ko)
+			if lexing != nil {
+				switch expression := rule.Front().Front(); {
+				case expression.GetType() == TypeAction:
+					return
+				case expression.GetType() != TypeNil && !lexing[name]:
+					lexing[name] = true
+					saved := references
+					printBegin()
+					printCaptures(expression)
+					compileChoice(expression, ko, cut, false)
+					printCover(rule.Front())
+					printEnd()
+					references = saved
+					delete(lexing, name)
+					return
+				}
+			}
+			if t.inlined(name) {
+				compileChoice(rule.Front(), ko, cut, false)
 				return
 			}
 			print("\n   if !rules[Rule%v]() {", name /*rule.GetId()*/)
@@ -1346,7 +2463,36 @@ func (t *Tree) Compile(file string) {
 			print("}")
 		case TypeAction:
 		case TypeCommit:
-		case TypePush:
+			if cutting {
+				print("\n   commit%d = true", cut)
+			}
+		case TypeBackReference:
+			for _, reference := range references {
+				if reference.name == n.String() {
+					print("\n   if !matchCapture(capture%d) {", reference.id)
+					printJump(ko)
+					print("}")
+				}
+			}
+		case TypePush, TypeLabel:
+			if lexing != nil {
+				referenced := false
+				for _, reference := range references {
+					referenced = referenced || n.GetType() == TypeLabel && reference.name == n.String()
+				}
+				if !referenced {
+					compile(n.Front(), ko)
+					return
+				}
+				ok := label
+				label++
+				printBegin()
+				print("\n   position%d := position", ok)
+				compile(n.Front(), ko)
+				printCapture(n, fmt.Sprintf("position%d", ok))
+				printEnd()
+				return
+			}
 			fallthrough
 		case TypeImplicitPush:
 			ok, element := label, n.Front()
@@ -1355,29 +2501,176 @@ func (t *Tree) Compile(file string) {
 			printBegin()
 			if nodeType == TypeAction {
 				print("\nadd(Rule%v, position)", rule)
+			} else if lexing == nil && t.lexer[rule.String()] {
+				saved := references
+				printCaptures(element)
+				suppressed := t.suppressed[rule.String()]
+				if suppressed {
+					print("\ntokenIndex%d := tokenIndex", ok)
+				} else {
+					print("\nposition%d, tokenIndex%d := position, tokenIndex", ok, ok)
+					print("\ndepth++")
+				}
+				lexing = map[string]bool{rule.String(): true}
+				compile(element, ko)
+				lexing = nil
+				print("\ntokenIndex = tokenIndex%d", ok)
+				if !suppressed {
+					print("\ndepth--")
+					print("\nadd(Rule%v, position%d)", rule, ok)
+				}
+				references = saved
+			} else if t.suppressed[rule.String()] {
+				saved := references
+				printCaptures(element)
+				compile(element, ko)
+				references = saved
 			} else {
+				saved := references
+				if n.GetType() == TypeImplicitPush {
+					printCaptures(element)
+				}
 				print("\nposition%d := position", ok)
 				print("\ndepth++")
 				compile(element, ko)
 				print("\ndepth--")
 				print("\nadd(Rule%v, position%d)", rule, ok)
+				references = saved
+				printCapture(n, fmt.Sprintf("position%d", ok))
+			}
+			printEnd()
+		case TypeRepeat:
+			min, max := repetitionBounds(n)
+			again := label
+			label++
+			out := label
+			label++
+			printBegin()
+			counted := min > 0 || max >= 0
+			if counted {
+				print("\n   count%d := 0", again)
+			}
+			printLabel(again)
+			if max >= 0 {
+				print("\n   if count%d < %d {", again, max)
+			}
+			printBegin()
+			printSave(out)
+			commits := hasCommit(n.Front())
+			if commits {
+				printCommit(out)
+			}
+			compileChoice(n.Front(), out, out, commits)
+			if counted {
+				print("\n   count%d++", again)
+			}
+			printJump(again)
+			printLabel(out)
+			if commits {
+				printCommitted(out, ko)
 			}
+			printRestore(out, true)
 			printEnd()
+			if max >= 0 {
+				print("\n   }")
+			}
+			if min > 0 {
+				print("\n   if count%d < %d {", again, min)
+				printJump(ko)
+				print("}")
+			}
+			printEnd()
+		case TypePrecedence:
+			/* precedence climbing, an operator binds its operands when min is at most its level */
+			climb, ok, fail, save := label, label+1, label+2, label+3
+			label += 4
+			print("\n   var climb%d func(min int) bool", climb)
+			print("\n   climb%d = func(min int) bool {", climb)
+			print("\n   position%d, tokenIndex%d := position, tokenIndex", ok, ok)
+			if labels[fail] {
+				printSave(fail)
+			}
+			compileChoice(n.Front(), fail, cut, false)
+			print("\n   for {")
+			printSave(save)
+			levels := n.Slice()[1:]
+			for l := len(levels) - 1; l >= 0; l-- {
+				next := l + 1
+				if levels[l].String() == "right" {
+					next = l
+				}
+				for _, operator := range levels[l].Slice() {
+					out := label
+					label++
+					print("\n   if min > %d {", l)
+					printJump(out)
+					print("}")
+					printBegin()
+					/* an action ending the operator runs once the right operand has been matched */
+					var action Node
+					if elements := operator.Slice(); operator.GetType() == TypeSequence {
+						last := elements[len(elements)-1]
+						if last.GetType() == TypeName && t.Rules[last.String()].Front().Front().GetType() == TypeAction {
+							for _, element := range elements[:len(elements)-1] {
+								compileChoice(element, out, cut, false)
+							}
+							action = last
+						}
+					}
+					if action == nil {
+						compileChoice(operator, out, cut, false)
+					}
+					print("\n   if !climb%d(%d) {", climb, next)
+					printJump(out)
+					print("}")
+					if action != nil {
+						printCover(operator)
+						compile(action, out)
+					}
+					print("\n   tree.deepen(tokenIndex%d, tokenIndex)", ok)
+					print("\n   add(Rule%v, position%d)", n, ok)
+					print("\n   continue")
+					printEnd()
+					printLabel(out)
+					printRestore(save, true)
+				}
+			}
+			print("\n   return true")
+			print("\n   }")
+			if labels[fail] {
+				printLabel(fail)
+				printRestore(fail, true)
+				print("\n   return false")
+			}
+			print("\n   }")
+			print("\n   if !climb%d(0) {", climb)
+			printJump(ko)
+			print("}")
 		case TypeAlternate:
 			ok := label
 			label++
 			printBegin()
 			elements := n.Slice()
 			printSave(ok)
+			commits := false
+			for _, element := range elements[:len(elements)-1] {
+				commits = commits || hasCommit(element)
+			}
+			if commits {
+				printCommit(ok)
+			}
 			for _, element := range elements[:len(elements)-1] {
 				next := label
 				label++
-				compile(element, next)
+				compileChoice(element, next, ok, commits)
 				printJump(ok)
 				printLabel(next)
-				printRestore(ok)
+				if commits {
+					printCommitted(ok, ko)
+				}
+				printRestore(ok, true)
 			}
-			compile(elements[len(elements)-1], ko)
+			compileChoice(elements[len(elements)-1], ko, ok, false)
 			printEnd()
 			printLabel(ok)
 		case TypeUnorderedAlternate:
@@ -1402,11 +2695,11 @@ func (t *Tree) Compile(file string) {
 					print(" '%s'", escape(character.String()))
 				}
 				print(":")
-				compile(sequence, done)
+				compileChoice(sequence, done, ok, false)
 				print("\nbreak")
 			}
 			print("\n   default:")
-			compile(last, done)
+			compileChoice(last, done, ok, false)
 			print("\nbreak")
 			print("\n   }")
 			printEnd()
@@ -1420,18 +2713,19 @@ func (t *Tree) Compile(file string) {
 			label++
 			printBegin()
 			printSave(ok)
-			compile(n.Front(), ko)
-			printRestore(ok)
+			compileChoice(n.Front(), ko, ok, false)
+			/* the predicate matched, so moving back isn't a backtrack */
+			printRestore(ok, false)
 			printEnd()
 		case TypePeekNot:
 			ok := label
 			label++
 			printBegin()
 			printSave(ok)
-			compile(n.Front(), ok)
+			compileChoice(n.Front(), ok, ok, false)
 			printJump(ko)
 			printLabel(ok)
-			printRestore(ok)
+			printRestore(ok, true)
 			printEnd()
 		case TypeQuery:
 			qko := label
@@ -1440,10 +2734,17 @@ func (t *Tree) Compile(file string) {
 			label++
 			printBegin()
 			printSave(qko)
-			compile(n.Front(), qko)
+			commits := hasCommit(n.Front())
+			if commits {
+				printCommit(qko)
+			}
+			compileChoice(n.Front(), qko, qko, commits)
 			printJump(qok)
 			printLabel(qko)
-			printRestore(qko)
+			if commits {
+				printCommitted(qko, ko)
+			}
+			printRestore(qko, true)
 			printEnd()
 			printLabel(qok)
 		case TypeStar:
@@ -1454,24 +2755,38 @@ func (t *Tree) Compile(file string) {
 			printLabel(again)
 			printBegin()
 			printSave(out)
-			compile(n.Front(), out)
+			commits := hasCommit(n.Front())
+			if commits {
+				printCommit(out)
+			}
+			compileChoice(n.Front(), out, out, commits)
 			printJump(again)
 			printLabel(out)
-			printRestore(out)
+			if commits {
+				printCommitted(out, ko)
+			}
+			printRestore(out, true)
 			printEnd()
 		case TypePlus:
 			again := label
 			label++
 			out := label
 			label++
-			compile(n.Front(), ko)
+			compileChoice(n.Front(), ko, out, false)
 			printLabel(again)
 			printBegin()
 			printSave(out)
-			compile(n.Front(), out)
+			commits := hasCommit(n.Front())
+			if commits {
+				printCommit(out)
+			}
+			compileChoice(n.Front(), out, out, commits)
 			printJump(again)
 			printLabel(out)
-			printRestore(out)
+			if commits {
+				printCommitted(out, ko)
+			}
+			printRestore(out, true)
 			printEnd()
 		case TypeNil:
 		default:
@@ -1491,9 +2806,7 @@ func (t *Tree) Compile(file string) {
 		}
 		ko := label
 		label++
-		if count, ok := t.rulesCount[element.String()]; !ok {
-			continue
-		} else if t.inline && count == 1 && ko != 0 {
+		if _, ok := t.rulesCount[element.String()]; !ok && !t.public[element.String()] {
 			continue
 		}
 		compile(expression, ko)
@@ -1508,7 +2821,9 @@ func (t *Tree) Compile(file string) {
 		}
 		expression := element.Front()
 		if expression.GetType() == TypeNil {
-			fmt.Fprintf(os.Stderr, "rule '%v' used but not defined\n", element)
+			if _, ok := t.rulesCount[element.String()]; ok {
+				fmt.Fprintf(os.Stderr, "rule '%v' used but not defined\n", element)
+			}
 			print("\n  nil,")
 			continue
 		}
@@ -1517,23 +2832,40 @@ func (t *Tree) Compile(file string) {
 		print("\n  /* %v ", element.GetId())
 		printRule(element)
 		print(" */")
-		if count, ok := t.rulesCount[element.String()]; !ok {
+		if _, ok := t.rulesCount[element.String()]; !ok && !t.public[element.String()] {
 			fmt.Fprintf(os.Stderr, "rule '%v' defined but not used\n", element)
 			print("\n  nil,")
 			continue
-		} else if t.inline && count == 1 && ko != 0 {
-			print("\n  nil,")
-			continue
 		}
-		print("\n  func() bool {")
+		profiled = element.String()
+		if instrumented := expression.Front().GetType() != TypeAction; instrumented && (t.Tracing || t.Profiling) {
+			print("\n  func() (matched bool) {")
+			if t.Tracing {
+				print("\n   if p.Trace != nil {\ntraced := trace(Rule%v)\ndefer func() { traced(matched) }()\n}", element)
+			}
+			if t.Profiling {
+				print("\n   started := profile(Rule%v)\ndefer func() { profiled(Rule%v, started, matched) }()", element, element)
+			}
+		} else {
+			print("\n  func() bool {")
+		}
+		if t.Incremental {
+			print("\n   if reuse(Rule%v) {", element)
+			if t.Profiling {
+				print("\np.Profile[Rule%v].Reused++", element)
+			}
+			printCover(expression)
+			print("\nreturn true\n}")
+		}
 		if labels[ko] {
 			printSave(ko)
 		}
 		compile(expression, ko)
+		printCover(expression)
 		print("\n   return true")
 		if labels[ko] {
 			printLabel(ko)
-			printRestore(ko)
+			printRestore(ko, true)
 			print("\n   return false")
 		}
 		print("\n  },")
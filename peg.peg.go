@@ -1,3 +1,6 @@
+// Code generated by peg peg.peg; DO NOT EDIT.
+// peg version dev sha256 f0605cb26c82f9ff1a55fb78626816e2963ffb2060391fd2ddc4cb301bb6afb3
+
 package main
 
 import (
@@ -6,6 +9,8 @@ import (
 	"math"
 	"sort"
 	"strconv"
+
+	"unicode/utf8"
 )
 
 const END_SYMBOL rune = 4
@@ -16,15 +21,22 @@ type Rule uint8
 const (
 	RuleUnknown Rule = iota
 	RuleGrammar
+	RuleDirective
 	RuleDefinition
+	RulePrecedence
 	RuleExpression
 	RuleSequence
+	RuleLabeled
 	RulePrefix
 	RuleSuffix
 	RulePrimary
 	RuleIdentifier
 	RuleIdentStart
 	RuleIdentCont
+	RuleLabel
+	RuleBackReference
+	RuleValueType
+	RuleValueTypeChar
 	RuleLiteral
 	RuleClass
 	RuleRanges
@@ -41,9 +53,13 @@ const (
 	RuleQuestion
 	RuleStar
 	RulePlus
+	RuleRepetition
 	RuleOpen
 	RuleClose
 	RuleDot
+	RuleColon
+	RuleAssociativity
+	RuleCommit
 	RuleSpacing
 	RuleComment
 	RuleSpace
@@ -65,6 +81,7 @@ const (
 	RuleAction9
 	RuleAction10
 	RuleAction11
+	RulePegText
 	RuleAction12
 	RuleAction13
 	RuleAction14
@@ -72,7 +89,6 @@ const (
 	RuleAction16
 	RuleAction17
 	RuleAction18
-	RulePegText
 	RuleAction19
 	RuleAction20
 	RuleAction21
@@ -100,6 +116,19 @@ const (
 	RuleAction43
 	RuleAction44
 	RuleAction45
+	RuleAction46
+	RuleAction47
+	RuleAction48
+	RuleAction49
+	RuleAction50
+	RuleAction51
+	RuleAction52
+	RuleAction53
+	RuleAction54
+	RuleAction55
+	RuleAction56
+	RuleAction57
+	RuleAction58
 
 	RulePre_
 	Rule_In_
@@ -109,15 +138,22 @@ const (
 var Rul3s = [...]string{
 	"Unknown",
 	"Grammar",
+	"Directive",
 	"Definition",
+	"Precedence",
 	"Expression",
 	"Sequence",
+	"Labeled",
 	"Prefix",
 	"Suffix",
 	"Primary",
 	"Identifier",
 	"IdentStart",
 	"IdentCont",
+	"Label",
+	"BackReference",
+	"ValueType",
+	"ValueTypeChar",
 	"Literal",
 	"Class",
 	"Ranges",
@@ -134,9 +170,13 @@ var Rul3s = [...]string{
 	"Question",
 	"Star",
 	"Plus",
+	"Repetition",
 	"Open",
 	"Close",
 	"Dot",
+	"Colon",
+	"Associativity",
+	"Commit",
 	"Spacing",
 	"Comment",
 	"Space",
@@ -158,6 +198,7 @@ var Rul3s = [...]string{
 	"Action9",
 	"Action10",
 	"Action11",
+	"PegText",
 	"Action12",
 	"Action13",
 	"Action14",
@@ -165,7 +206,6 @@ var Rul3s = [...]string{
 	"Action16",
 	"Action17",
 	"Action18",
-	"PegText",
 	"Action19",
 	"Action20",
 	"Action21",
@@ -193,6 +233,19 @@ var Rul3s = [...]string{
 	"Action43",
 	"Action44",
 	"Action45",
+	"Action46",
+	"Action47",
+	"Action48",
+	"Action49",
+	"Action50",
+	"Action51",
+	"Action52",
+	"Action53",
+	"Action54",
+	"Action55",
+	"Action56",
+	"Action57",
+	"Action58",
 
 	"Pre_",
 	"_In_",
@@ -239,6 +292,7 @@ type tokens16 struct {
 
 func (t *tokens16) trim(length int) {
 	t.tree = t.tree[0:length]
+	t.ordered = nil
 }
 
 func (t *tokens16) Print() {
@@ -469,6 +523,7 @@ type tokens32 struct {
 
 func (t *tokens32) trim(length int) {
 	t.tree = t.tree[0:length]
+	t.ordered = nil
 }
 
 func (t *tokens32) Print() {
@@ -672,7 +727,9 @@ func (t *tokens32) Error() []token32 {
 
 func (t *tokens16) Expand(index int) TokenTree {
 	tree := t.tree
-	if index >= len(tree) {
+	if index < cap(tree) && index >= len(tree) {
+		t.tree = tree[:cap(tree)]
+	} else if index >= len(tree) {
 		expanded := make([]token32, 2*len(tree))
 		for i, v := range tree {
 			expanded[i] = v.GetToken32()
@@ -684,7 +741,9 @@ func (t *tokens16) Expand(index int) TokenTree {
 
 func (t *tokens32) Expand(index int) TokenTree {
 	tree := t.tree
-	if index >= len(tree) {
+	if index < cap(tree) && index >= len(tree) {
+		t.tree = tree[:cap(tree)]
+	} else if index >= len(tree) {
 		expanded := make([]token32, 2*len(tree))
 		copy(expanded, tree)
 		t.tree = expanded
@@ -697,9 +756,11 @@ type Peg struct {
 
 	Buffer string
 	buffer []rune
-	rules  [86]func() bool
+	rules  [110]func() bool
+	match  func(rule Rule, offset int) (int, bool)
 	Parse  func(rule ...int) error
 	Reset  func()
+
 	TokenTree
 }
 
@@ -766,12 +827,30 @@ func (p *Peg) Highlighter() {
 	p.TokenTree.PrintSyntax()
 }
 
+// The tokens hold rune offsets while the actions index the buffer in bytes, so this translates
+// a rune offset into a byte offset when the text isn't made of single byte runes.
+func byteOffset(text string) func(offset int32) int {
+	if len(text) == utf8.RuneCountInString(text) {
+		return func(offset int32) int { return int(offset) }
+	}
+	offsets := make([]int, 0, len(text)+1)
+	for i := range text {
+		offsets = append(offsets, i)
+	}
+	offsets = append(offsets, len(text))
+	return func(offset int32) int { return offsets[offset] }
+}
+
 func (p *Peg) Execute() {
-	buffer, begin, end := p.Buffer, 0, 0
+	buffer, begin, end, at := p.Buffer, 0, 0, byteOffset(p.Buffer)
+	/* the actions may only use labels */
+	_, _, _ = buffer, begin, end
+
 	for token := range p.TokenTree.Tokens() {
 		switch token.Rule {
 		case RulePegText:
-			begin, end = int(token.begin), int(token.end)
+			begin, end = at(token.begin), at(token.end)
+
 		case RuleAction0:
 			p.AddPackage(buffer[begin:end])
 		case RuleAction1:
@@ -779,101 +858,165 @@ func (p *Peg) Execute() {
 		case RuleAction2:
 			p.AddState(buffer[begin:end])
 		case RuleAction3:
-			p.AddRule(buffer[begin:end])
+			p.AddDirective(buffer[begin:end])
 		case RuleAction4:
-			p.AddExpression()
+			p.AddDirectiveArgument(buffer[begin:end])
 		case RuleAction5:
-			p.AddAlternate()
+			p.AddRule(buffer[begin:end])
+			p.Locate(begin, end)
 		case RuleAction6:
+			p.AddRule(buffer[begin:end])
+			p.Locate(begin, end)
+			p.AddSuppressed()
+		case RuleAction7:
+			p.AddExpression()
+		case RuleAction8:
+			p.AddValue(buffer[begin:end])
+		case RuleAction9:
+			p.AddValueAction(buffer[begin:end])
+		case RuleAction10:
+			p.AddOperators(buffer[begin:end])
+		case RuleAction11:
+			p.AddPrecedence()
+		case RuleAction12:
+			p.Locate(begin, end)
+		case RuleAction13:
+			p.Locate(begin, end)
+			p.AddAlternate()
+		case RuleAction14:
 			p.AddNil()
 			p.AddAlternate()
-		case RuleAction7:
+		case RuleAction15:
 			p.AddNil()
-		case RuleAction8:
+		case RuleAction16:
 			p.AddSequence()
-		case RuleAction9:
+		case RuleAction17:
+			p.AddLabel(buffer[begin:end])
+		case RuleAction18:
+			p.AddLabeled()
+		case RuleAction19:
 			p.AddPredicate(buffer[begin:end])
-		case RuleAction10:
+		case RuleAction20:
 			p.AddPeekFor()
-		case RuleAction11:
+		case RuleAction21:
 			p.AddPeekNot()
-		case RuleAction12:
+		case RuleAction22:
 			p.AddQuery()
-		case RuleAction13:
+		case RuleAction23:
 			p.AddStar()
-		case RuleAction14:
+		case RuleAction24:
 			p.AddPlus()
-		case RuleAction15:
+		case RuleAction25:
+			p.AddRepetition(buffer[begin:end])
+		case RuleAction26:
 			p.AddName(buffer[begin:end])
-		case RuleAction16:
+		case RuleAction27:
 			p.AddDot()
-		case RuleAction17:
+		case RuleAction28:
+			p.AddCommit()
+		case RuleAction29:
+			p.AddBackReference(buffer[begin:end])
+		case RuleAction30:
 			p.AddAction(buffer[begin:end])
-		case RuleAction18:
+		case RuleAction31:
 			p.AddPush()
-		case RuleAction19:
+		case RuleAction32:
 			p.AddSequence()
-		case RuleAction20:
+		case RuleAction33:
 			p.AddSequence()
-		case RuleAction21:
+		case RuleAction34:
 			p.AddPeekNot()
 			p.AddDot()
 			p.AddSequence()
-		case RuleAction22:
+		case RuleAction35:
 			p.AddPeekNot()
 			p.AddDot()
 			p.AddSequence()
-		case RuleAction23:
+		case RuleAction36:
 			p.AddAlternate()
-		case RuleAction24:
+		case RuleAction37:
 			p.AddAlternate()
-		case RuleAction25:
+		case RuleAction38:
 			p.AddRange()
-		case RuleAction26:
+		case RuleAction39:
 			p.AddDoubleRange()
-		case RuleAction27:
+		case RuleAction40:
 			p.AddCharacter(buffer[begin:end])
-		case RuleAction28:
+		case RuleAction41:
 			p.AddDoubleCharacter(buffer[begin:end])
-		case RuleAction29:
+		case RuleAction42:
 			p.AddCharacter(buffer[begin:end])
-		case RuleAction30:
+		case RuleAction43:
 			p.AddCharacter("\a")
-		case RuleAction31:
+		case RuleAction44:
 			p.AddCharacter("\b")
-		case RuleAction32:
+		case RuleAction45:
 			p.AddCharacter("\x1B")
-		case RuleAction33:
+		case RuleAction46:
 			p.AddCharacter("\f")
-		case RuleAction34:
+		case RuleAction47:
 			p.AddCharacter("\n")
-		case RuleAction35:
+		case RuleAction48:
 			p.AddCharacter("\r")
-		case RuleAction36:
+		case RuleAction49:
 			p.AddCharacter("\t")
-		case RuleAction37:
+		case RuleAction50:
 			p.AddCharacter("\v")
-		case RuleAction38:
+		case RuleAction51:
 			p.AddCharacter("'")
-		case RuleAction39:
+		case RuleAction52:
 			p.AddCharacter("\"")
-		case RuleAction40:
+		case RuleAction53:
 			p.AddCharacter("[")
-		case RuleAction41:
+		case RuleAction54:
 			p.AddCharacter("]")
-		case RuleAction42:
+		case RuleAction55:
 			p.AddCharacter("-")
-		case RuleAction43:
+		case RuleAction56:
 			p.AddOctalCharacter(buffer[begin:end])
-		case RuleAction44:
+		case RuleAction57:
 			p.AddOctalCharacter(buffer[begin:end])
-		case RuleAction45:
+		case RuleAction58:
 			p.AddCharacter("\\")
 
 		}
 	}
 }
 
+/* ParseRule parses the buffer starting with the given rule, which must be used or public. */
+func (p *Peg) ParseRule(rule Rule) error {
+	if rule == RuleUnknown || int(rule) >= len(p.rules) || p.rules[rule] == nil {
+		name := fmt.Sprint(int(rule))
+		if int(rule) < len(Rul3s) {
+			name = Rul3s[rule]
+		}
+		return fmt.Errorf("rule %v isn't an entry point", name)
+	}
+	return p.Parse(int(rule))
+}
+
+/* ParseString parses the input starting with the given rule. */
+func (p *Peg) ParseString(rule Rule, input string) error {
+	p.Buffer = input
+	p.Init()
+	return p.ParseRule(rule)
+}
+
+// Match matches the rule at the rune offset of the input, without requiring the rest of the
+// input to match, and returns the rune offset where the match ended. The rune buffer is only
+// rebuilt when the input changes, so a rule can be matched repeatedly along the same input.
+func (p *Peg) Match(rule Rule, input string, offset int) (end int, ok bool) {
+	if p.match == nil || input != p.Buffer {
+		p.Buffer = input
+		p.Init()
+	}
+	if rule == RuleUnknown || int(rule) >= len(p.rules) || p.rules[rule] == nil ||
+		offset < 0 || offset >= len(p.buffer) {
+		return 0, false
+	}
+	return p.match(rule, offset)
+}
+
 func (p *Peg) Init() {
 	p.buffer = []rune(p.Buffer)
 	if len(p.buffer) == 0 || p.buffer[len(p.buffer)-1] != END_SYMBOL {
@@ -888,10 +1031,12 @@ func (p *Peg) Init() {
 		if len(rule) > 0 {
 			r = rule[0]
 		}
+
 		matches := p.rules[r]()
 		p.TokenTree = tree
 		if matches {
 			p.TokenTree.trim(tokenIndex)
+
 			return nil
 		}
 		return &parseError{p}
@@ -899,6 +1044,19 @@ func (p *Peg) Init() {
 
 	p.Reset = func() {
 		position, tokenIndex, depth = 0, 0, 0
+
+	}
+
+	p.match = func(rule Rule, offset int) (int, bool) {
+		position, tokenIndex, depth = offset, 0, 0
+
+		matches := rules[rule]()
+		p.TokenTree = tree
+		if !matches {
+			return 0, false
+		}
+		p.TokenTree.trim(tokenIndex)
+		return position, true
 	}
 
 	add := func(rule Rule, begin int) {
@@ -906,6 +1064,7 @@ func (p *Peg) Init() {
 			tree = t
 		}
 		tree.Add(rule, begin, position, depth, tokenIndex)
+
 		tokenIndex++
 	}
 
@@ -935,7 +1094,7 @@ func (p *Peg) Init() {
 
 	rules = [...]func() bool{
 		nil,
-		/* 0 Grammar <- <(Spacing ('p' 'a' 'c' 'k' 'a' 'g' 'e') Spacing Identifier Action0 ('t' 'y' 'p' 'e') Spacing Identifier Action1 ('P' 'e' 'g') Spacing Action Action2 Definition+ EndOfFile)> */
+		/* 0 Grammar <- <(Spacing ('p' 'a' 'c' 'k' 'a' 'g' 'e') Spacing Identifier Action0 ('t' 'y' 'p' 'e') Spacing Identifier Action1 ('P' 'e' 'g') Spacing Action Action2 (Directive / Definition)+ EndOfFile)> */
 		func() bool {
 			position0, tokenIndex0, depth0 := position, tokenIndex, depth
 			{
@@ -1027,15 +1186,35 @@ func (p *Peg) Init() {
 				if !rules[RuleAction2]() {
 					goto l0
 				}
-				if !rules[RuleDefinition]() {
-					goto l0
+				{
+					position4, tokenIndex4, depth4 := position, tokenIndex, depth
+					if !rules[RuleDirective]() {
+						goto l5
+					}
+					goto l4
+				l5:
+					position, tokenIndex, depth = position4, tokenIndex4, depth4
+					if !rules[RuleDefinition]() {
+						goto l0
+					}
 				}
+			l4:
 			l2:
 				{
 					position3, tokenIndex3, depth3 := position, tokenIndex, depth
-					if !rules[RuleDefinition]() {
-						goto l3
+					{
+						position6, tokenIndex6, depth6 := position, tokenIndex, depth
+						if !rules[RuleDirective]() {
+							goto l7
+						}
+						goto l6
+					l7:
+						position, tokenIndex, depth = position6, tokenIndex6, depth6
+						if !rules[RuleDefinition]() {
+							goto l3
+						}
 					}
+				l6:
 					goto l2
 				l3:
 					position, tokenIndex, depth = position3, tokenIndex3, depth3
@@ -1051,2207 +1230,2946 @@ func (p *Peg) Init() {
 			position, tokenIndex, depth = position0, tokenIndex0, depth0
 			return false
 		},
-		/* 1 Definition <- <(Identifier Action3 LeftArrow Expression Action4 &((Identifier LeftArrow) / !.))> */
+		/* 1 Directive <- <('%' Identifier Action3 (Identifier !LeftArrow Action4)*)> */
 		func() bool {
-			position4, tokenIndex4, depth4 := position, tokenIndex, depth
+			position8, tokenIndex8, depth8 := position, tokenIndex, depth
 			{
-				position5 := position
+				position9 := position
 				depth++
+				if buffer[position] != rune('%') {
+					goto l8
+				}
+				position++
 				if !rules[RuleIdentifier]() {
-					goto l4
+					goto l8
 				}
 				if !rules[RuleAction3]() {
-					goto l4
+					goto l8
+				}
+			l10:
+				{
+					position11, tokenIndex11, depth11 := position, tokenIndex, depth
+					if !rules[RuleIdentifier]() {
+						goto l11
+					}
+					{
+						position12, tokenIndex12, depth12 := position, tokenIndex, depth
+						if !rules[RuleLeftArrow]() {
+							goto l12
+						}
+						goto l11
+					l12:
+						position, tokenIndex, depth = position12, tokenIndex12, depth12
+					}
+					if !rules[RuleAction4]() {
+						goto l11
+					}
+					goto l10
+				l11:
+					position, tokenIndex, depth = position11, tokenIndex11, depth11
+				}
+				depth--
+				add(RuleDirective, position9)
+			}
+			return true
+		l8:
+			position, tokenIndex, depth = position8, tokenIndex8, depth8
+			return false
+		},
+		/* 2 Definition <- <(((Identifier Action5) / ('~' Identifier Action6)) LeftArrow Expression Precedence* Action7 (Colon ValueType Action8 Action Action9)? &((Identifier LeftArrow) / '~' / '%' / !.))> */
+		func() bool {
+			position13, tokenIndex13, depth13 := position, tokenIndex, depth
+			{
+				position14 := position
+				depth++
+				{
+					position15, tokenIndex15, depth15 := position, tokenIndex, depth
+					if !rules[RuleIdentifier]() {
+						goto l16
+					}
+					if !rules[RuleAction5]() {
+						goto l16
+					}
+					goto l15
+				l16:
+					position, tokenIndex, depth = position15, tokenIndex15, depth15
+					if buffer[position] != rune('~') {
+						goto l13
+					}
+					position++
+					if !rules[RuleIdentifier]() {
+						goto l13
+					}
+					if !rules[RuleAction6]() {
+						goto l13
+					}
 				}
+			l15:
 				if !rules[RuleLeftArrow]() {
-					goto l4
+					goto l13
 				}
 				if !rules[RuleExpression]() {
-					goto l4
+					goto l13
 				}
-				if !rules[RuleAction4]() {
-					goto l4
+			l17:
+				{
+					position18, tokenIndex18, depth18 := position, tokenIndex, depth
+					if !rules[RulePrecedence]() {
+						goto l18
+					}
+					goto l17
+				l18:
+					position, tokenIndex, depth = position18, tokenIndex18, depth18
+				}
+				if !rules[RuleAction7]() {
+					goto l13
+				}
+				{
+					position19, tokenIndex19, depth19 := position, tokenIndex, depth
+					if !rules[RuleColon]() {
+						goto l19
+					}
+					if !rules[RuleValueType]() {
+						goto l19
+					}
+					if !rules[RuleAction8]() {
+						goto l19
+					}
+					if !rules[RuleAction]() {
+						goto l19
+					}
+					if !rules[RuleAction9]() {
+						goto l19
+					}
+					goto l20
+				l19:
+					position, tokenIndex, depth = position19, tokenIndex19, depth19
 				}
+			l20:
 				{
-					position6, tokenIndex6, depth6 := position, tokenIndex, depth
+					position21, tokenIndex21, depth21 := position, tokenIndex, depth
 					{
-						position7, tokenIndex7, depth7 := position, tokenIndex, depth
+						position22, tokenIndex22, depth22 := position, tokenIndex, depth
 						if !rules[RuleIdentifier]() {
-							goto l8
+							goto l23
 						}
 						if !rules[RuleLeftArrow]() {
-							goto l8
+							goto l23
+						}
+						goto l22
+					l23:
+						position, tokenIndex, depth = position22, tokenIndex22, depth22
+						if buffer[position] != rune('~') {
+							goto l24
+						}
+						position++
+						goto l22
+					l24:
+						position, tokenIndex, depth = position22, tokenIndex22, depth22
+						if buffer[position] != rune('%') {
+							goto l25
 						}
-						goto l7
-					l8:
-						position, tokenIndex, depth = position7, tokenIndex7, depth7
+						position++
+						goto l22
+					l25:
+						position, tokenIndex, depth = position22, tokenIndex22, depth22
 						{
-							position9, tokenIndex9, depth9 := position, tokenIndex, depth
+							position26, tokenIndex26, depth26 := position, tokenIndex, depth
 							if !matchDot() {
-								goto l9
+								goto l26
 							}
-							goto l4
-						l9:
-							position, tokenIndex, depth = position9, tokenIndex9, depth9
+							goto l13
+						l26:
+							position, tokenIndex, depth = position26, tokenIndex26, depth26
 						}
 					}
-				l7:
-					position, tokenIndex, depth = position6, tokenIndex6, depth6
+				l22:
+					position, tokenIndex, depth = position21, tokenIndex21, depth21
+				}
+				depth--
+				add(RuleDefinition, position14)
+			}
+			return true
+		l13:
+			position, tokenIndex, depth = position13, tokenIndex13, depth13
+			return false
+		},
+		/* 3 Precedence <- <('%' Associativity Action10 Expression Action11)> */
+		func() bool {
+			position27, tokenIndex27, depth27 := position, tokenIndex, depth
+			{
+				position28 := position
+				depth++
+				if buffer[position] != rune('%') {
+					goto l27
+				}
+				position++
+				if !rules[RuleAssociativity]() {
+					goto l27
+				}
+				if !rules[RuleAction10]() {
+					goto l27
+				}
+				if !rules[RuleExpression]() {
+					goto l27
+				}
+				if !rules[RuleAction11]() {
+					goto l27
 				}
 				depth--
-				add(RuleDefinition, position5)
+				add(RulePrecedence, position28)
 			}
 			return true
-		l4:
-			position, tokenIndex, depth = position4, tokenIndex4, depth4
+		l27:
+			position, tokenIndex, depth = position27, tokenIndex27, depth27
 			return false
 		},
-		/* 2 Expression <- <((Sequence (Slash Sequence Action5)* (Slash Action6)?) / Action7)> */
+		/* 4 Expression <- <((<Sequence> Action12 (Slash <Sequence> Action13)* (Slash Action14)?) / Action15)> */
 		func() bool {
-			position10, tokenIndex10, depth10 := position, tokenIndex, depth
+			position29, tokenIndex29, depth29 := position, tokenIndex, depth
 			{
-				position11 := position
+				position30 := position
 				depth++
 				{
-					position12, tokenIndex12, depth12 := position, tokenIndex, depth
-					if !rules[RuleSequence]() {
-						goto l13
+					position31, tokenIndex31, depth31 := position, tokenIndex, depth
+					{
+						position33 := position
+						depth++
+						if !rules[RuleSequence]() {
+							goto l32
+						}
+						depth--
+						add(RulePegText, position33)
+					}
+					if !rules[RuleAction12]() {
+						goto l32
 					}
-				l14:
+				l34:
 					{
-						position15, tokenIndex15, depth15 := position, tokenIndex, depth
+						position35, tokenIndex35, depth35 := position, tokenIndex, depth
 						if !rules[RuleSlash]() {
-							goto l15
+							goto l35
 						}
-						if !rules[RuleSequence]() {
-							goto l15
+						{
+							position36 := position
+							depth++
+							if !rules[RuleSequence]() {
+								goto l35
+							}
+							depth--
+							add(RulePegText, position36)
 						}
-						if !rules[RuleAction5]() {
-							goto l15
+						if !rules[RuleAction13]() {
+							goto l35
 						}
-						goto l14
-					l15:
-						position, tokenIndex, depth = position15, tokenIndex15, depth15
+						goto l34
+					l35:
+						position, tokenIndex, depth = position35, tokenIndex35, depth35
 					}
 					{
-						position16, tokenIndex16, depth16 := position, tokenIndex, depth
+						position37, tokenIndex37, depth37 := position, tokenIndex, depth
 						if !rules[RuleSlash]() {
-							goto l16
+							goto l37
 						}
-						if !rules[RuleAction6]() {
-							goto l16
+						if !rules[RuleAction14]() {
+							goto l37
 						}
-						goto l17
-					l16:
-						position, tokenIndex, depth = position16, tokenIndex16, depth16
+						goto l38
+					l37:
+						position, tokenIndex, depth = position37, tokenIndex37, depth37
 					}
-				l17:
-					goto l12
-				l13:
-					position, tokenIndex, depth = position12, tokenIndex12, depth12
-					if !rules[RuleAction7]() {
-						goto l10
+				l38:
+					goto l31
+				l32:
+					position, tokenIndex, depth = position31, tokenIndex31, depth31
+					if !rules[RuleAction15]() {
+						goto l29
 					}
 				}
-			l12:
+			l31:
 				depth--
-				add(RuleExpression, position11)
+				add(RuleExpression, position30)
 			}
 			return true
-		l10:
-			position, tokenIndex, depth = position10, tokenIndex10, depth10
+		l29:
+			position, tokenIndex, depth = position29, tokenIndex29, depth29
 			return false
 		},
-		/* 3 Sequence <- <(Prefix (Prefix Action8)*)> */
+		/* 5 Sequence <- <(Labeled (Labeled Action16)*)> */
 		func() bool {
-			position18, tokenIndex18, depth18 := position, tokenIndex, depth
+			position39, tokenIndex39, depth39 := position, tokenIndex, depth
 			{
-				position19 := position
+				position40 := position
 				depth++
-				if !rules[RulePrefix]() {
-					goto l18
+				if !rules[RuleLabeled]() {
+					goto l39
 				}
-			l20:
+			l41:
 				{
-					position21, tokenIndex21, depth21 := position, tokenIndex, depth
+					position42, tokenIndex42, depth42 := position, tokenIndex, depth
+					if !rules[RuleLabeled]() {
+						goto l42
+					}
+					if !rules[RuleAction16]() {
+						goto l42
+					}
+					goto l41
+				l42:
+					position, tokenIndex, depth = position42, tokenIndex42, depth42
+				}
+				depth--
+				add(RuleSequence, position40)
+			}
+			return true
+		l39:
+			position, tokenIndex, depth = position39, tokenIndex39, depth39
+			return false
+		},
+		/* 6 Labeled <- <((Label Action17 Prefix Action18) / Prefix)> */
+		func() bool {
+			position43, tokenIndex43, depth43 := position, tokenIndex, depth
+			{
+				position44 := position
+				depth++
+				{
+					position45, tokenIndex45, depth45 := position, tokenIndex, depth
+					if !rules[RuleLabel]() {
+						goto l46
+					}
+					if !rules[RuleAction17]() {
+						goto l46
+					}
 					if !rules[RulePrefix]() {
-						goto l21
+						goto l46
 					}
-					if !rules[RuleAction8]() {
-						goto l21
+					if !rules[RuleAction18]() {
+						goto l46
+					}
+					goto l45
+				l46:
+					position, tokenIndex, depth = position45, tokenIndex45, depth45
+					if !rules[RulePrefix]() {
+						goto l43
 					}
-					goto l20
-				l21:
-					position, tokenIndex, depth = position21, tokenIndex21, depth21
 				}
+			l45:
 				depth--
-				add(RuleSequence, position19)
+				add(RuleLabeled, position44)
 			}
 			return true
-		l18:
-			position, tokenIndex, depth = position18, tokenIndex18, depth18
+		l43:
+			position, tokenIndex, depth = position43, tokenIndex43, depth43
 			return false
 		},
-		/* 4 Prefix <- <((And Action Action9) / (And Suffix Action10) / (Not Suffix Action11) / Suffix)> */
+		/* 7 Prefix <- <((And Action Action19) / (And Suffix Action20) / (Not Suffix Action21) / Suffix)> */
 		func() bool {
-			position22, tokenIndex22, depth22 := position, tokenIndex, depth
+			position47, tokenIndex47, depth47 := position, tokenIndex, depth
 			{
-				position23 := position
+				position48 := position
 				depth++
 				{
-					position24, tokenIndex24, depth24 := position, tokenIndex, depth
+					position49, tokenIndex49, depth49 := position, tokenIndex, depth
 					if !rules[RuleAnd]() {
-						goto l25
+						goto l50
 					}
 					if !rules[RuleAction]() {
-						goto l25
+						goto l50
 					}
-					if !rules[RuleAction9]() {
-						goto l25
+					if !rules[RuleAction19]() {
+						goto l50
 					}
-					goto l24
-				l25:
-					position, tokenIndex, depth = position24, tokenIndex24, depth24
+					goto l49
+				l50:
+					position, tokenIndex, depth = position49, tokenIndex49, depth49
 					if !rules[RuleAnd]() {
-						goto l26
+						goto l51
 					}
 					if !rules[RuleSuffix]() {
-						goto l26
+						goto l51
 					}
-					if !rules[RuleAction10]() {
-						goto l26
+					if !rules[RuleAction20]() {
+						goto l51
 					}
-					goto l24
-				l26:
-					position, tokenIndex, depth = position24, tokenIndex24, depth24
+					goto l49
+				l51:
+					position, tokenIndex, depth = position49, tokenIndex49, depth49
 					if !rules[RuleNot]() {
-						goto l27
+						goto l52
 					}
 					if !rules[RuleSuffix]() {
-						goto l27
+						goto l52
 					}
-					if !rules[RuleAction11]() {
-						goto l27
+					if !rules[RuleAction21]() {
+						goto l52
 					}
-					goto l24
-				l27:
-					position, tokenIndex, depth = position24, tokenIndex24, depth24
+					goto l49
+				l52:
+					position, tokenIndex, depth = position49, tokenIndex49, depth49
 					if !rules[RuleSuffix]() {
-						goto l22
+						goto l47
 					}
 				}
-			l24:
+			l49:
 				depth--
-				add(RulePrefix, position23)
+				add(RulePrefix, position48)
 			}
 			return true
-		l22:
-			position, tokenIndex, depth = position22, tokenIndex22, depth22
+		l47:
+			position, tokenIndex, depth = position47, tokenIndex47, depth47
 			return false
 		},
-		/* 5 Suffix <- <(Primary ((Question Action12) / (Star Action13) / (Plus Action14))?)> */
+		/* 8 Suffix <- <(Primary ((Question Action22) / (Star Action23) / (Plus Action24) / (Repetition Action25))?)> */
 		func() bool {
-			position28, tokenIndex28, depth28 := position, tokenIndex, depth
+			position53, tokenIndex53, depth53 := position, tokenIndex, depth
 			{
-				position29 := position
+				position54 := position
 				depth++
 				if !rules[RulePrimary]() {
-					goto l28
+					goto l53
 				}
 				{
-					position30, tokenIndex30, depth30 := position, tokenIndex, depth
+					position55, tokenIndex55, depth55 := position, tokenIndex, depth
 					{
-						position32, tokenIndex32, depth32 := position, tokenIndex, depth
+						position57, tokenIndex57, depth57 := position, tokenIndex, depth
 						if !rules[RuleQuestion]() {
-							goto l33
+							goto l58
 						}
-						if !rules[RuleAction12]() {
-							goto l33
+						if !rules[RuleAction22]() {
+							goto l58
 						}
-						goto l32
-					l33:
-						position, tokenIndex, depth = position32, tokenIndex32, depth32
+						goto l57
+					l58:
+						position, tokenIndex, depth = position57, tokenIndex57, depth57
 						if !rules[RuleStar]() {
-							goto l34
+							goto l59
 						}
-						if !rules[RuleAction13]() {
-							goto l34
+						if !rules[RuleAction23]() {
+							goto l59
 						}
-						goto l32
-					l34:
-						position, tokenIndex, depth = position32, tokenIndex32, depth32
+						goto l57
+					l59:
+						position, tokenIndex, depth = position57, tokenIndex57, depth57
 						if !rules[RulePlus]() {
-							goto l30
+							goto l60
 						}
-						if !rules[RuleAction14]() {
-							goto l30
+						if !rules[RuleAction24]() {
+							goto l60
+						}
+						goto l57
+					l60:
+						position, tokenIndex, depth = position57, tokenIndex57, depth57
+						if !rules[RuleRepetition]() {
+							goto l55
+						}
+						if !rules[RuleAction25]() {
+							goto l55
 						}
 					}
-				l32:
-					goto l31
-				l30:
-					position, tokenIndex, depth = position30, tokenIndex30, depth30
+				l57:
+					goto l56
+				l55:
+					position, tokenIndex, depth = position55, tokenIndex55, depth55
 				}
-			l31:
+			l56:
 				depth--
-				add(RuleSuffix, position29)
+				add(RuleSuffix, position54)
 			}
 			return true
-		l28:
-			position, tokenIndex, depth = position28, tokenIndex28, depth28
+		l53:
+			position, tokenIndex, depth = position53, tokenIndex53, depth53
 			return false
 		},
-		/* 6 Primary <- <((Identifier !LeftArrow Action15) / (Open Expression Close) / Literal / Class / (Dot Action16) / (Action Action17) / (Begin Expression End Action18))> */
+		/* 9 Primary <- <((Identifier !LeftArrow Action26) / (Open Expression Close) / Literal / Class / (Dot Action27) / (Commit Action28) / (BackReference Action29) / (Action Action30) / (Begin Expression End Action31))> */
 		func() bool {
-			position35, tokenIndex35, depth35 := position, tokenIndex, depth
+			position61, tokenIndex61, depth61 := position, tokenIndex, depth
 			{
-				position36 := position
+				position62 := position
 				depth++
 				{
-					position37, tokenIndex37, depth37 := position, tokenIndex, depth
+					position63, tokenIndex63, depth63 := position, tokenIndex, depth
 					if !rules[RuleIdentifier]() {
-						goto l38
+						goto l64
 					}
 					{
-						position39, tokenIndex39, depth39 := position, tokenIndex, depth
+						position65, tokenIndex65, depth65 := position, tokenIndex, depth
 						if !rules[RuleLeftArrow]() {
-							goto l39
+							goto l65
 						}
-						goto l38
-					l39:
-						position, tokenIndex, depth = position39, tokenIndex39, depth39
+						goto l64
+					l65:
+						position, tokenIndex, depth = position65, tokenIndex65, depth65
 					}
-					if !rules[RuleAction15]() {
-						goto l38
+					if !rules[RuleAction26]() {
+						goto l64
 					}
-					goto l37
-				l38:
-					position, tokenIndex, depth = position37, tokenIndex37, depth37
+					goto l63
+				l64:
+					position, tokenIndex, depth = position63, tokenIndex63, depth63
 					if !rules[RuleOpen]() {
-						goto l40
+						goto l66
 					}
 					if !rules[RuleExpression]() {
-						goto l40
+						goto l66
 					}
 					if !rules[RuleClose]() {
-						goto l40
+						goto l66
 					}
-					goto l37
-				l40:
-					position, tokenIndex, depth = position37, tokenIndex37, depth37
+					goto l63
+				l66:
+					position, tokenIndex, depth = position63, tokenIndex63, depth63
 					if !rules[RuleLiteral]() {
-						goto l41
+						goto l67
 					}
-					goto l37
-				l41:
-					position, tokenIndex, depth = position37, tokenIndex37, depth37
+					goto l63
+				l67:
+					position, tokenIndex, depth = position63, tokenIndex63, depth63
 					if !rules[RuleClass]() {
-						goto l42
+						goto l68
 					}
-					goto l37
-				l42:
-					position, tokenIndex, depth = position37, tokenIndex37, depth37
+					goto l63
+				l68:
+					position, tokenIndex, depth = position63, tokenIndex63, depth63
 					if !rules[RuleDot]() {
-						goto l43
+						goto l69
 					}
-					if !rules[RuleAction16]() {
-						goto l43
+					if !rules[RuleAction27]() {
+						goto l69
 					}
-					goto l37
-				l43:
-					position, tokenIndex, depth = position37, tokenIndex37, depth37
+					goto l63
+				l69:
+					position, tokenIndex, depth = position63, tokenIndex63, depth63
+					if !rules[RuleCommit]() {
+						goto l70
+					}
+					if !rules[RuleAction28]() {
+						goto l70
+					}
+					goto l63
+				l70:
+					position, tokenIndex, depth = position63, tokenIndex63, depth63
+					if !rules[RuleBackReference]() {
+						goto l71
+					}
+					if !rules[RuleAction29]() {
+						goto l71
+					}
+					goto l63
+				l71:
+					position, tokenIndex, depth = position63, tokenIndex63, depth63
 					if !rules[RuleAction]() {
-						goto l44
+						goto l72
 					}
-					if !rules[RuleAction17]() {
-						goto l44
+					if !rules[RuleAction30]() {
+						goto l72
 					}
-					goto l37
-				l44:
-					position, tokenIndex, depth = position37, tokenIndex37, depth37
+					goto l63
+				l72:
+					position, tokenIndex, depth = position63, tokenIndex63, depth63
 					if !rules[RuleBegin]() {
-						goto l35
+						goto l61
 					}
 					if !rules[RuleExpression]() {
-						goto l35
+						goto l61
 					}
 					if !rules[RuleEnd]() {
-						goto l35
+						goto l61
 					}
-					if !rules[RuleAction18]() {
-						goto l35
+					if !rules[RuleAction31]() {
+						goto l61
 					}
 				}
-			l37:
+			l63:
 				depth--
-				add(RulePrimary, position36)
+				add(RulePrimary, position62)
 			}
 			return true
-		l35:
-			position, tokenIndex, depth = position35, tokenIndex35, depth35
+		l61:
+			position, tokenIndex, depth = position61, tokenIndex61, depth61
 			return false
 		},
-		/* 7 Identifier <- <(<(IdentStart IdentCont*)> Spacing)> */
+		/* 10 Identifier <- <(<(IdentStart IdentCont*)> Spacing)> */
 		func() bool {
-			position45, tokenIndex45, depth45 := position, tokenIndex, depth
+			position73, tokenIndex73, depth73 := position, tokenIndex, depth
 			{
-				position46 := position
+				position74 := position
 				depth++
 				{
-					position47 := position
+					position75 := position
 					depth++
 					if !rules[RuleIdentStart]() {
-						goto l45
+						goto l73
 					}
-				l48:
+				l76:
 					{
-						position49, tokenIndex49, depth49 := position, tokenIndex, depth
+						position77, tokenIndex77, depth77 := position, tokenIndex, depth
 						if !rules[RuleIdentCont]() {
-							goto l49
+							goto l77
 						}
-						goto l48
-					l49:
-						position, tokenIndex, depth = position49, tokenIndex49, depth49
+						goto l76
+					l77:
+						position, tokenIndex, depth = position77, tokenIndex77, depth77
 					}
 					depth--
-					add(RulePegText, position47)
+					add(RulePegText, position75)
 				}
 				if !rules[RuleSpacing]() {
-					goto l45
+					goto l73
 				}
 				depth--
-				add(RuleIdentifier, position46)
+				add(RuleIdentifier, position74)
 			}
 			return true
-		l45:
-			position, tokenIndex, depth = position45, tokenIndex45, depth45
+		l73:
+			position, tokenIndex, depth = position73, tokenIndex73, depth73
 			return false
 		},
-		/* 8 IdentStart <- <([a-z] / [A-Z] / '_')> */
+		/* 11 IdentStart <- <([a-z] / [A-Z] / '_')> */
 		func() bool {
-			position50, tokenIndex50, depth50 := position, tokenIndex, depth
+			position78, tokenIndex78, depth78 := position, tokenIndex, depth
 			{
-				position51 := position
+				position79 := position
 				depth++
 				{
-					position52, tokenIndex52, depth52 := position, tokenIndex, depth
+					position80, tokenIndex80, depth80 := position, tokenIndex, depth
 					if c := buffer[position]; c < rune('a') || c > rune('z') {
-						goto l53
+						goto l81
 					}
 					position++
-					goto l52
-				l53:
-					position, tokenIndex, depth = position52, tokenIndex52, depth52
+					goto l80
+				l81:
+					position, tokenIndex, depth = position80, tokenIndex80, depth80
 					if c := buffer[position]; c < rune('A') || c > rune('Z') {
-						goto l54
+						goto l82
 					}
 					position++
-					goto l52
-				l54:
-					position, tokenIndex, depth = position52, tokenIndex52, depth52
+					goto l80
+				l82:
+					position, tokenIndex, depth = position80, tokenIndex80, depth80
 					if buffer[position] != rune('_') {
-						goto l50
+						goto l78
 					}
 					position++
 				}
-			l52:
+			l80:
 				depth--
-				add(RuleIdentStart, position51)
+				add(RuleIdentStart, position79)
 			}
 			return true
-		l50:
-			position, tokenIndex, depth = position50, tokenIndex50, depth50
+		l78:
+			position, tokenIndex, depth = position78, tokenIndex78, depth78
 			return false
 		},
-		/* 9 IdentCont <- <(IdentStart / [0-9])> */
+		/* 12 IdentCont <- <(IdentStart / [0-9])> */
 		func() bool {
-			position55, tokenIndex55, depth55 := position, tokenIndex, depth
+			position83, tokenIndex83, depth83 := position, tokenIndex, depth
 			{
-				position56 := position
+				position84 := position
 				depth++
 				{
-					position57, tokenIndex57, depth57 := position, tokenIndex, depth
+					position85, tokenIndex85, depth85 := position, tokenIndex, depth
 					if !rules[RuleIdentStart]() {
-						goto l58
+						goto l86
 					}
-					goto l57
-				l58:
-					position, tokenIndex, depth = position57, tokenIndex57, depth57
+					goto l85
+				l86:
+					position, tokenIndex, depth = position85, tokenIndex85, depth85
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l55
+						goto l83
 					}
 					position++
 				}
-			l57:
+			l85:
 				depth--
-				add(RuleIdentCont, position56)
+				add(RuleIdentCont, position84)
 			}
 			return true
-		l55:
-			position, tokenIndex, depth = position55, tokenIndex55, depth55
+		l83:
+			position, tokenIndex, depth = position83, tokenIndex83, depth83
 			return false
 		},
-		/* 10 Literal <- <(('\'' (!'\'' Char)? (!'\'' Char Action19)* '\'' Spacing) / ('"' (!'"' DoubleChar)? (!'"' DoubleChar Action20)* '"' Spacing))> */
+		/* 13 Label <- <(<(IdentStart IdentCont*)> ':' Spacing)> */
 		func() bool {
-			position59, tokenIndex59, depth59 := position, tokenIndex, depth
+			position87, tokenIndex87, depth87 := position, tokenIndex, depth
 			{
-				position60 := position
+				position88 := position
 				depth++
 				{
-					position61, tokenIndex61, depth61 := position, tokenIndex, depth
+					position89 := position
+					depth++
+					if !rules[RuleIdentStart]() {
+						goto l87
+					}
+				l90:
+					{
+						position91, tokenIndex91, depth91 := position, tokenIndex, depth
+						if !rules[RuleIdentCont]() {
+							goto l91
+						}
+						goto l90
+					l91:
+						position, tokenIndex, depth = position91, tokenIndex91, depth91
+					}
+					depth--
+					add(RulePegText, position89)
+				}
+				if buffer[position] != rune(':') {
+					goto l87
+				}
+				position++
+				if !rules[RuleSpacing]() {
+					goto l87
+				}
+				depth--
+				add(RuleLabel, position88)
+			}
+			return true
+		l87:
+			position, tokenIndex, depth = position87, tokenIndex87, depth87
+			return false
+		},
+		/* 14 BackReference <- <('$' <(IdentStart IdentCont*)> Spacing)> */
+		func() bool {
+			position92, tokenIndex92, depth92 := position, tokenIndex, depth
+			{
+				position93 := position
+				depth++
+				if buffer[position] != rune('$') {
+					goto l92
+				}
+				position++
+				{
+					position94 := position
+					depth++
+					if !rules[RuleIdentStart]() {
+						goto l92
+					}
+				l95:
+					{
+						position96, tokenIndex96, depth96 := position, tokenIndex, depth
+						if !rules[RuleIdentCont]() {
+							goto l96
+						}
+						goto l95
+					l96:
+						position, tokenIndex, depth = position96, tokenIndex96, depth96
+					}
+					depth--
+					add(RulePegText, position94)
+				}
+				if !rules[RuleSpacing]() {
+					goto l92
+				}
+				depth--
+				add(RuleBackReference, position93)
+			}
+			return true
+		l92:
+			position, tokenIndex, depth = position92, tokenIndex92, depth92
+			return false
+		},
+		/* 15 ValueType <- <(<(ValueTypeChar+ ((' ' / '\t')+ ValueTypeChar+)*)> Spacing)> */
+		func() bool {
+			position97, tokenIndex97, depth97 := position, tokenIndex, depth
+			{
+				position98 := position
+				depth++
+				{
+					position99 := position
+					depth++
+					if !rules[RuleValueTypeChar]() {
+						goto l97
+					}
+				l100:
+					{
+						position101, tokenIndex101, depth101 := position, tokenIndex, depth
+						if !rules[RuleValueTypeChar]() {
+							goto l101
+						}
+						goto l100
+					l101:
+						position, tokenIndex, depth = position101, tokenIndex101, depth101
+					}
+				l102:
+					{
+						position103, tokenIndex103, depth103 := position, tokenIndex, depth
+						{
+							position106, tokenIndex106, depth106 := position, tokenIndex, depth
+							if buffer[position] != rune(' ') {
+								goto l107
+							}
+							position++
+							goto l106
+						l107:
+							position, tokenIndex, depth = position106, tokenIndex106, depth106
+							if buffer[position] != rune('\t') {
+								goto l103
+							}
+							position++
+						}
+					l106:
+					l104:
+						{
+							position105, tokenIndex105, depth105 := position, tokenIndex, depth
+							{
+								position108, tokenIndex108, depth108 := position, tokenIndex, depth
+								if buffer[position] != rune(' ') {
+									goto l109
+								}
+								position++
+								goto l108
+							l109:
+								position, tokenIndex, depth = position108, tokenIndex108, depth108
+								if buffer[position] != rune('\t') {
+									goto l105
+								}
+								position++
+							}
+						l108:
+							goto l104
+						l105:
+							position, tokenIndex, depth = position105, tokenIndex105, depth105
+						}
+						if !rules[RuleValueTypeChar]() {
+							goto l103
+						}
+					l110:
+						{
+							position111, tokenIndex111, depth111 := position, tokenIndex, depth
+							if !rules[RuleValueTypeChar]() {
+								goto l111
+							}
+							goto l110
+						l111:
+							position, tokenIndex, depth = position111, tokenIndex111, depth111
+						}
+						goto l102
+					l103:
+						position, tokenIndex, depth = position103, tokenIndex103, depth103
+					}
+					depth--
+					add(RulePegText, position99)
+				}
+				if !rules[RuleSpacing]() {
+					goto l97
+				}
+				depth--
+				add(RuleValueType, position98)
+			}
+			return true
+		l97:
+			position, tokenIndex, depth = position97, tokenIndex97, depth97
+			return false
+		},
+		/* 16 ValueTypeChar <- <(('{' (' ' / '\t')* '}') / (!'{' !Space .))> */
+		func() bool {
+			position112, tokenIndex112, depth112 := position, tokenIndex, depth
+			{
+				position113 := position
+				depth++
+				{
+					position114, tokenIndex114, depth114 := position, tokenIndex, depth
+					if buffer[position] != rune('{') {
+						goto l115
+					}
+					position++
+				l116:
+					{
+						position117, tokenIndex117, depth117 := position, tokenIndex, depth
+						{
+							position118, tokenIndex118, depth118 := position, tokenIndex, depth
+							if buffer[position] != rune(' ') {
+								goto l119
+							}
+							position++
+							goto l118
+						l119:
+							position, tokenIndex, depth = position118, tokenIndex118, depth118
+							if buffer[position] != rune('\t') {
+								goto l117
+							}
+							position++
+						}
+					l118:
+						goto l116
+					l117:
+						position, tokenIndex, depth = position117, tokenIndex117, depth117
+					}
+					if buffer[position] != rune('}') {
+						goto l115
+					}
+					position++
+					goto l114
+				l115:
+					position, tokenIndex, depth = position114, tokenIndex114, depth114
+					{
+						position120, tokenIndex120, depth120 := position, tokenIndex, depth
+						if buffer[position] != rune('{') {
+							goto l120
+						}
+						position++
+						goto l112
+					l120:
+						position, tokenIndex, depth = position120, tokenIndex120, depth120
+					}
+					{
+						position121, tokenIndex121, depth121 := position, tokenIndex, depth
+						if !rules[RuleSpace]() {
+							goto l121
+						}
+						goto l112
+					l121:
+						position, tokenIndex, depth = position121, tokenIndex121, depth121
+					}
+					if !matchDot() {
+						goto l112
+					}
+				}
+			l114:
+				depth--
+				add(RuleValueTypeChar, position113)
+			}
+			return true
+		l112:
+			position, tokenIndex, depth = position112, tokenIndex112, depth112
+			return false
+		},
+		/* 17 Literal <- <(('\'' (!'\'' Char)? (!'\'' Char Action32)* '\'' Spacing) / ('"' (!'"' DoubleChar)? (!'"' DoubleChar Action33)* '"' Spacing))> */
+		func() bool {
+			position122, tokenIndex122, depth122 := position, tokenIndex, depth
+			{
+				position123 := position
+				depth++
+				{
+					position124, tokenIndex124, depth124 := position, tokenIndex, depth
 					if buffer[position] != rune('\'') {
-						goto l62
+						goto l125
 					}
 					position++
 					{
-						position63, tokenIndex63, depth63 := position, tokenIndex, depth
+						position126, tokenIndex126, depth126 := position, tokenIndex, depth
 						{
-							position65, tokenIndex65, depth65 := position, tokenIndex, depth
+							position128, tokenIndex128, depth128 := position, tokenIndex, depth
 							if buffer[position] != rune('\'') {
-								goto l65
+								goto l128
 							}
 							position++
-							goto l63
-						l65:
-							position, tokenIndex, depth = position65, tokenIndex65, depth65
+							goto l126
+						l128:
+							position, tokenIndex, depth = position128, tokenIndex128, depth128
 						}
 						if !rules[RuleChar]() {
-							goto l63
+							goto l126
 						}
-						goto l64
-					l63:
-						position, tokenIndex, depth = position63, tokenIndex63, depth63
+						goto l127
+					l126:
+						position, tokenIndex, depth = position126, tokenIndex126, depth126
 					}
-				l64:
-				l66:
+				l127:
+				l129:
 					{
-						position67, tokenIndex67, depth67 := position, tokenIndex, depth
+						position130, tokenIndex130, depth130 := position, tokenIndex, depth
 						{
-							position68, tokenIndex68, depth68 := position, tokenIndex, depth
+							position131, tokenIndex131, depth131 := position, tokenIndex, depth
 							if buffer[position] != rune('\'') {
-								goto l68
+								goto l131
 							}
 							position++
-							goto l67
-						l68:
-							position, tokenIndex, depth = position68, tokenIndex68, depth68
+							goto l130
+						l131:
+							position, tokenIndex, depth = position131, tokenIndex131, depth131
 						}
 						if !rules[RuleChar]() {
-							goto l67
+							goto l130
 						}
-						if !rules[RuleAction19]() {
-							goto l67
+						if !rules[RuleAction32]() {
+							goto l130
 						}
-						goto l66
-					l67:
-						position, tokenIndex, depth = position67, tokenIndex67, depth67
+						goto l129
+					l130:
+						position, tokenIndex, depth = position130, tokenIndex130, depth130
 					}
 					if buffer[position] != rune('\'') {
-						goto l62
+						goto l125
 					}
 					position++
 					if !rules[RuleSpacing]() {
-						goto l62
+						goto l125
 					}
-					goto l61
-				l62:
-					position, tokenIndex, depth = position61, tokenIndex61, depth61
+					goto l124
+				l125:
+					position, tokenIndex, depth = position124, tokenIndex124, depth124
 					if buffer[position] != rune('"') {
-						goto l59
+						goto l122
 					}
 					position++
 					{
-						position69, tokenIndex69, depth69 := position, tokenIndex, depth
+						position132, tokenIndex132, depth132 := position, tokenIndex, depth
 						{
-							position71, tokenIndex71, depth71 := position, tokenIndex, depth
+							position134, tokenIndex134, depth134 := position, tokenIndex, depth
 							if buffer[position] != rune('"') {
-								goto l71
+								goto l134
 							}
 							position++
-							goto l69
-						l71:
-							position, tokenIndex, depth = position71, tokenIndex71, depth71
+							goto l132
+						l134:
+							position, tokenIndex, depth = position134, tokenIndex134, depth134
 						}
 						if !rules[RuleDoubleChar]() {
-							goto l69
+							goto l132
 						}
-						goto l70
-					l69:
-						position, tokenIndex, depth = position69, tokenIndex69, depth69
+						goto l133
+					l132:
+						position, tokenIndex, depth = position132, tokenIndex132, depth132
 					}
-				l70:
-				l72:
+				l133:
+				l135:
 					{
-						position73, tokenIndex73, depth73 := position, tokenIndex, depth
+						position136, tokenIndex136, depth136 := position, tokenIndex, depth
 						{
-							position74, tokenIndex74, depth74 := position, tokenIndex, depth
+							position137, tokenIndex137, depth137 := position, tokenIndex, depth
 							if buffer[position] != rune('"') {
-								goto l74
+								goto l137
 							}
 							position++
-							goto l73
-						l74:
-							position, tokenIndex, depth = position74, tokenIndex74, depth74
+							goto l136
+						l137:
+							position, tokenIndex, depth = position137, tokenIndex137, depth137
 						}
 						if !rules[RuleDoubleChar]() {
-							goto l73
+							goto l136
 						}
-						if !rules[RuleAction20]() {
-							goto l73
+						if !rules[RuleAction33]() {
+							goto l136
 						}
-						goto l72
-					l73:
-						position, tokenIndex, depth = position73, tokenIndex73, depth73
+						goto l135
+					l136:
+						position, tokenIndex, depth = position136, tokenIndex136, depth136
 					}
 					if buffer[position] != rune('"') {
-						goto l59
+						goto l122
 					}
 					position++
 					if !rules[RuleSpacing]() {
-						goto l59
+						goto l122
 					}
 				}
-			l61:
+			l124:
 				depth--
-				add(RuleLiteral, position60)
+				add(RuleLiteral, position123)
 			}
 			return true
-		l59:
-			position, tokenIndex, depth = position59, tokenIndex59, depth59
+		l122:
+			position, tokenIndex, depth = position122, tokenIndex122, depth122
 			return false
 		},
-		/* 11 Class <- <((('[' '[' (('^' DoubleRanges Action21) / DoubleRanges)? (']' ']')) / ('[' (('^' Ranges Action22) / Ranges)? ']')) Spacing)> */
+		/* 18 Class <- <((('[' '[' (('^' DoubleRanges Action34) / DoubleRanges)? (']' ']')) / ('[' (('^' Ranges Action35) / Ranges)? ']')) Spacing)> */
 		func() bool {
-			position75, tokenIndex75, depth75 := position, tokenIndex, depth
+			position138, tokenIndex138, depth138 := position, tokenIndex, depth
 			{
-				position76 := position
+				position139 := position
 				depth++
 				{
-					position77, tokenIndex77, depth77 := position, tokenIndex, depth
+					position140, tokenIndex140, depth140 := position, tokenIndex, depth
 					if buffer[position] != rune('[') {
-						goto l78
+						goto l141
 					}
 					position++
 					if buffer[position] != rune('[') {
-						goto l78
+						goto l141
 					}
 					position++
 					{
-						position79, tokenIndex79, depth79 := position, tokenIndex, depth
+						position142, tokenIndex142, depth142 := position, tokenIndex, depth
 						{
-							position81, tokenIndex81, depth81 := position, tokenIndex, depth
+							position144, tokenIndex144, depth144 := position, tokenIndex, depth
 							if buffer[position] != rune('^') {
-								goto l82
+								goto l145
 							}
 							position++
 							if !rules[RuleDoubleRanges]() {
-								goto l82
+								goto l145
 							}
-							if !rules[RuleAction21]() {
-								goto l82
+							if !rules[RuleAction34]() {
+								goto l145
 							}
-							goto l81
-						l82:
-							position, tokenIndex, depth = position81, tokenIndex81, depth81
+							goto l144
+						l145:
+							position, tokenIndex, depth = position144, tokenIndex144, depth144
 							if !rules[RuleDoubleRanges]() {
-								goto l79
+								goto l142
 							}
 						}
-					l81:
-						goto l80
-					l79:
-						position, tokenIndex, depth = position79, tokenIndex79, depth79
+					l144:
+						goto l143
+					l142:
+						position, tokenIndex, depth = position142, tokenIndex142, depth142
 					}
-				l80:
+				l143:
 					if buffer[position] != rune(']') {
-						goto l78
+						goto l141
 					}
 					position++
 					if buffer[position] != rune(']') {
-						goto l78
+						goto l141
 					}
 					position++
-					goto l77
-				l78:
-					position, tokenIndex, depth = position77, tokenIndex77, depth77
+					goto l140
+				l141:
+					position, tokenIndex, depth = position140, tokenIndex140, depth140
 					if buffer[position] != rune('[') {
-						goto l75
+						goto l138
 					}
 					position++
 					{
-						position83, tokenIndex83, depth83 := position, tokenIndex, depth
+						position146, tokenIndex146, depth146 := position, tokenIndex, depth
 						{
-							position85, tokenIndex85, depth85 := position, tokenIndex, depth
+							position148, tokenIndex148, depth148 := position, tokenIndex, depth
 							if buffer[position] != rune('^') {
-								goto l86
+								goto l149
 							}
 							position++
 							if !rules[RuleRanges]() {
-								goto l86
+								goto l149
 							}
-							if !rules[RuleAction22]() {
-								goto l86
+							if !rules[RuleAction35]() {
+								goto l149
 							}
-							goto l85
-						l86:
-							position, tokenIndex, depth = position85, tokenIndex85, depth85
+							goto l148
+						l149:
+							position, tokenIndex, depth = position148, tokenIndex148, depth148
 							if !rules[RuleRanges]() {
-								goto l83
+								goto l146
 							}
 						}
-					l85:
-						goto l84
-					l83:
-						position, tokenIndex, depth = position83, tokenIndex83, depth83
+					l148:
+						goto l147
+					l146:
+						position, tokenIndex, depth = position146, tokenIndex146, depth146
 					}
-				l84:
+				l147:
 					if buffer[position] != rune(']') {
-						goto l75
+						goto l138
 					}
 					position++
 				}
-			l77:
+			l140:
 				if !rules[RuleSpacing]() {
-					goto l75
+					goto l138
 				}
 				depth--
-				add(RuleClass, position76)
+				add(RuleClass, position139)
 			}
 			return true
-		l75:
-			position, tokenIndex, depth = position75, tokenIndex75, depth75
+		l138:
+			position, tokenIndex, depth = position138, tokenIndex138, depth138
 			return false
 		},
-		/* 12 Ranges <- <(!']' Range (!']' Range Action23)*)> */
+		/* 19 Ranges <- <(!']' Range (!']' Range Action36)*)> */
 		func() bool {
-			position87, tokenIndex87, depth87 := position, tokenIndex, depth
+			position150, tokenIndex150, depth150 := position, tokenIndex, depth
 			{
-				position88 := position
+				position151 := position
 				depth++
 				{
-					position89, tokenIndex89, depth89 := position, tokenIndex, depth
+					position152, tokenIndex152, depth152 := position, tokenIndex, depth
 					if buffer[position] != rune(']') {
-						goto l89
+						goto l152
 					}
 					position++
-					goto l87
-				l89:
-					position, tokenIndex, depth = position89, tokenIndex89, depth89
+					goto l150
+				l152:
+					position, tokenIndex, depth = position152, tokenIndex152, depth152
 				}
 				if !rules[RuleRange]() {
-					goto l87
+					goto l150
 				}
-			l90:
+			l153:
 				{
-					position91, tokenIndex91, depth91 := position, tokenIndex, depth
+					position154, tokenIndex154, depth154 := position, tokenIndex, depth
 					{
-						position92, tokenIndex92, depth92 := position, tokenIndex, depth
+						position155, tokenIndex155, depth155 := position, tokenIndex, depth
 						if buffer[position] != rune(']') {
-							goto l92
+							goto l155
 						}
 						position++
-						goto l91
-					l92:
-						position, tokenIndex, depth = position92, tokenIndex92, depth92
+						goto l154
+					l155:
+						position, tokenIndex, depth = position155, tokenIndex155, depth155
 					}
 					if !rules[RuleRange]() {
-						goto l91
+						goto l154
 					}
-					if !rules[RuleAction23]() {
-						goto l91
+					if !rules[RuleAction36]() {
+						goto l154
 					}
-					goto l90
-				l91:
-					position, tokenIndex, depth = position91, tokenIndex91, depth91
+					goto l153
+				l154:
+					position, tokenIndex, depth = position154, tokenIndex154, depth154
 				}
 				depth--
-				add(RuleRanges, position88)
+				add(RuleRanges, position151)
 			}
 			return true
-		l87:
-			position, tokenIndex, depth = position87, tokenIndex87, depth87
+		l150:
+			position, tokenIndex, depth = position150, tokenIndex150, depth150
 			return false
 		},
-		/* 13 DoubleRanges <- <(!(']' ']') DoubleRange (!(']' ']') DoubleRange Action24)*)> */
+		/* 20 DoubleRanges <- <(!(']' ']') DoubleRange (!(']' ']') DoubleRange Action37)*)> */
 		func() bool {
-			position93, tokenIndex93, depth93 := position, tokenIndex, depth
+			position156, tokenIndex156, depth156 := position, tokenIndex, depth
 			{
-				position94 := position
+				position157 := position
 				depth++
 				{
-					position95, tokenIndex95, depth95 := position, tokenIndex, depth
+					position158, tokenIndex158, depth158 := position, tokenIndex, depth
 					if buffer[position] != rune(']') {
-						goto l95
+						goto l158
 					}
 					position++
 					if buffer[position] != rune(']') {
-						goto l95
+						goto l158
 					}
 					position++
-					goto l93
-				l95:
-					position, tokenIndex, depth = position95, tokenIndex95, depth95
+					goto l156
+				l158:
+					position, tokenIndex, depth = position158, tokenIndex158, depth158
 				}
 				if !rules[RuleDoubleRange]() {
-					goto l93
+					goto l156
 				}
-			l96:
+			l159:
 				{
-					position97, tokenIndex97, depth97 := position, tokenIndex, depth
+					position160, tokenIndex160, depth160 := position, tokenIndex, depth
 					{
-						position98, tokenIndex98, depth98 := position, tokenIndex, depth
+						position161, tokenIndex161, depth161 := position, tokenIndex, depth
 						if buffer[position] != rune(']') {
-							goto l98
+							goto l161
 						}
 						position++
 						if buffer[position] != rune(']') {
-							goto l98
+							goto l161
 						}
 						position++
-						goto l97
-					l98:
-						position, tokenIndex, depth = position98, tokenIndex98, depth98
+						goto l160
+					l161:
+						position, tokenIndex, depth = position161, tokenIndex161, depth161
 					}
 					if !rules[RuleDoubleRange]() {
-						goto l97
+						goto l160
 					}
-					if !rules[RuleAction24]() {
-						goto l97
+					if !rules[RuleAction37]() {
+						goto l160
 					}
-					goto l96
-				l97:
-					position, tokenIndex, depth = position97, tokenIndex97, depth97
+					goto l159
+				l160:
+					position, tokenIndex, depth = position160, tokenIndex160, depth160
 				}
 				depth--
-				add(RuleDoubleRanges, position94)
+				add(RuleDoubleRanges, position157)
 			}
 			return true
-		l93:
-			position, tokenIndex, depth = position93, tokenIndex93, depth93
+		l156:
+			position, tokenIndex, depth = position156, tokenIndex156, depth156
 			return false
 		},
-		/* 14 Range <- <((Char '-' Char Action25) / Char)> */
+		/* 21 Range <- <((Char '-' Char Action38) / Char)> */
 		func() bool {
-			position99, tokenIndex99, depth99 := position, tokenIndex, depth
+			position162, tokenIndex162, depth162 := position, tokenIndex, depth
 			{
-				position100 := position
+				position163 := position
 				depth++
 				{
-					position101, tokenIndex101, depth101 := position, tokenIndex, depth
+					position164, tokenIndex164, depth164 := position, tokenIndex, depth
 					if !rules[RuleChar]() {
-						goto l102
+						goto l165
 					}
 					if buffer[position] != rune('-') {
-						goto l102
+						goto l165
 					}
 					position++
 					if !rules[RuleChar]() {
-						goto l102
+						goto l165
 					}
-					if !rules[RuleAction25]() {
-						goto l102
+					if !rules[RuleAction38]() {
+						goto l165
 					}
-					goto l101
-				l102:
-					position, tokenIndex, depth = position101, tokenIndex101, depth101
+					goto l164
+				l165:
+					position, tokenIndex, depth = position164, tokenIndex164, depth164
 					if !rules[RuleChar]() {
-						goto l99
+						goto l162
 					}
 				}
-			l101:
+			l164:
 				depth--
-				add(RuleRange, position100)
+				add(RuleRange, position163)
 			}
 			return true
-		l99:
-			position, tokenIndex, depth = position99, tokenIndex99, depth99
+		l162:
+			position, tokenIndex, depth = position162, tokenIndex162, depth162
 			return false
 		},
-		/* 15 DoubleRange <- <((Char '-' Char Action26) / DoubleChar)> */
+		/* 22 DoubleRange <- <((Char '-' Char Action39) / DoubleChar)> */
 		func() bool {
-			position103, tokenIndex103, depth103 := position, tokenIndex, depth
+			position166, tokenIndex166, depth166 := position, tokenIndex, depth
 			{
-				position104 := position
+				position167 := position
 				depth++
 				{
-					position105, tokenIndex105, depth105 := position, tokenIndex, depth
+					position168, tokenIndex168, depth168 := position, tokenIndex, depth
 					if !rules[RuleChar]() {
-						goto l106
+						goto l169
 					}
 					if buffer[position] != rune('-') {
-						goto l106
+						goto l169
 					}
 					position++
 					if !rules[RuleChar]() {
-						goto l106
+						goto l169
 					}
-					if !rules[RuleAction26]() {
-						goto l106
+					if !rules[RuleAction39]() {
+						goto l169
 					}
-					goto l105
-				l106:
-					position, tokenIndex, depth = position105, tokenIndex105, depth105
+					goto l168
+				l169:
+					position, tokenIndex, depth = position168, tokenIndex168, depth168
 					if !rules[RuleDoubleChar]() {
-						goto l103
+						goto l166
 					}
 				}
-			l105:
+			l168:
 				depth--
-				add(RuleDoubleRange, position104)
+				add(RuleDoubleRange, position167)
 			}
 			return true
-		l103:
-			position, tokenIndex, depth = position103, tokenIndex103, depth103
+		l166:
+			position, tokenIndex, depth = position166, tokenIndex166, depth166
 			return false
 		},
-		/* 16 Char <- <(Escape / (!'\\' <.> Action27))> */
+		/* 23 Char <- <(Escape / (!'\\' <.> Action40))> */
 		func() bool {
-			position107, tokenIndex107, depth107 := position, tokenIndex, depth
+			position170, tokenIndex170, depth170 := position, tokenIndex, depth
 			{
-				position108 := position
+				position171 := position
 				depth++
 				{
-					position109, tokenIndex109, depth109 := position, tokenIndex, depth
+					position172, tokenIndex172, depth172 := position, tokenIndex, depth
 					if !rules[RuleEscape]() {
-						goto l110
+						goto l173
 					}
-					goto l109
-				l110:
-					position, tokenIndex, depth = position109, tokenIndex109, depth109
+					goto l172
+				l173:
+					position, tokenIndex, depth = position172, tokenIndex172, depth172
 					{
-						position111, tokenIndex111, depth111 := position, tokenIndex, depth
+						position174, tokenIndex174, depth174 := position, tokenIndex, depth
 						if buffer[position] != rune('\\') {
-							goto l111
+							goto l174
 						}
 						position++
-						goto l107
-					l111:
-						position, tokenIndex, depth = position111, tokenIndex111, depth111
+						goto l170
+					l174:
+						position, tokenIndex, depth = position174, tokenIndex174, depth174
 					}
 					{
-						position112 := position
+						position175 := position
 						depth++
 						if !matchDot() {
-							goto l107
+							goto l170
 						}
 						depth--
-						add(RulePegText, position112)
+						add(RulePegText, position175)
 					}
-					if !rules[RuleAction27]() {
-						goto l107
+					if !rules[RuleAction40]() {
+						goto l170
 					}
 				}
-			l109:
+			l172:
 				depth--
-				add(RuleChar, position108)
+				add(RuleChar, position171)
 			}
 			return true
-		l107:
-			position, tokenIndex, depth = position107, tokenIndex107, depth107
+		l170:
+			position, tokenIndex, depth = position170, tokenIndex170, depth170
 			return false
 		},
-		/* 17 DoubleChar <- <(Escape / (<([a-z] / [A-Z])> Action28) / (!'\\' <.> Action29))> */
+		/* 24 DoubleChar <- <(Escape / (<([a-z] / [A-Z])> Action41) / (!'\\' <.> Action42))> */
 		func() bool {
-			position113, tokenIndex113, depth113 := position, tokenIndex, depth
+			position176, tokenIndex176, depth176 := position, tokenIndex, depth
 			{
-				position114 := position
+				position177 := position
 				depth++
 				{
-					position115, tokenIndex115, depth115 := position, tokenIndex, depth
+					position178, tokenIndex178, depth178 := position, tokenIndex, depth
 					if !rules[RuleEscape]() {
-						goto l116
+						goto l179
 					}
-					goto l115
-				l116:
-					position, tokenIndex, depth = position115, tokenIndex115, depth115
+					goto l178
+				l179:
+					position, tokenIndex, depth = position178, tokenIndex178, depth178
 					{
-						position118 := position
+						position181 := position
 						depth++
 						{
-							position119, tokenIndex119, depth119 := position, tokenIndex, depth
+							position182, tokenIndex182, depth182 := position, tokenIndex, depth
 							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l120
+								goto l183
 							}
 							position++
-							goto l119
-						l120:
-							position, tokenIndex, depth = position119, tokenIndex119, depth119
+							goto l182
+						l183:
+							position, tokenIndex, depth = position182, tokenIndex182, depth182
 							if c := buffer[position]; c < rune('A') || c > rune('Z') {
-								goto l117
+								goto l180
 							}
 							position++
 						}
-					l119:
+					l182:
 						depth--
-						add(RulePegText, position118)
+						add(RulePegText, position181)
 					}
-					if !rules[RuleAction28]() {
-						goto l117
+					if !rules[RuleAction41]() {
+						goto l180
 					}
-					goto l115
-				l117:
-					position, tokenIndex, depth = position115, tokenIndex115, depth115
+					goto l178
+				l180:
+					position, tokenIndex, depth = position178, tokenIndex178, depth178
 					{
-						position121, tokenIndex121, depth121 := position, tokenIndex, depth
+						position184, tokenIndex184, depth184 := position, tokenIndex, depth
 						if buffer[position] != rune('\\') {
-							goto l121
+							goto l184
 						}
 						position++
-						goto l113
-					l121:
-						position, tokenIndex, depth = position121, tokenIndex121, depth121
+						goto l176
+					l184:
+						position, tokenIndex, depth = position184, tokenIndex184, depth184
 					}
 					{
-						position122 := position
+						position185 := position
 						depth++
 						if !matchDot() {
-							goto l113
+							goto l176
 						}
 						depth--
-						add(RulePegText, position122)
+						add(RulePegText, position185)
 					}
-					if !rules[RuleAction29]() {
-						goto l113
+					if !rules[RuleAction42]() {
+						goto l176
 					}
 				}
-			l115:
+			l178:
 				depth--
-				add(RuleDoubleChar, position114)
+				add(RuleDoubleChar, position177)
 			}
 			return true
-		l113:
-			position, tokenIndex, depth = position113, tokenIndex113, depth113
+		l176:
+			position, tokenIndex, depth = position176, tokenIndex176, depth176
 			return false
 		},
-		/* 18 Escape <- <(('\\' ('a' / 'A') Action30) / ('\\' ('b' / 'B') Action31) / ('\\' ('e' / 'E') Action32) / ('\\' ('f' / 'F') Action33) / ('\\' ('n' / 'N') Action34) / ('\\' ('r' / 'R') Action35) / ('\\' ('t' / 'T') Action36) / ('\\' ('v' / 'V') Action37) / ('\\' '\'' Action38) / ('\\' '"' Action39) / ('\\' '[' Action40) / ('\\' ']' Action41) / ('\\' '-' Action42) / ('\\' <([0-3] [0-7] [0-7])> Action43) / ('\\' <([0-7] [0-7]?)> Action44) / ('\\' '\\' Action45))> */
+		/* 25 Escape <- <(('\\' ('a' / 'A') Action43) / ('\\' ('b' / 'B') Action44) / ('\\' ('e' / 'E') Action45) / ('\\' ('f' / 'F') Action46) / ('\\' ('n' / 'N') Action47) / ('\\' ('r' / 'R') Action48) / ('\\' ('t' / 'T') Action49) / ('\\' ('v' / 'V') Action50) / ('\\' '\'' Action51) / ('\\' '"' Action52) / ('\\' '[' Action53) / ('\\' ']' Action54) / ('\\' '-' Action55) / ('\\' <([0-3] [0-7] [0-7])> Action56) / ('\\' <([0-7] [0-7]?)> Action57) / ('\\' '\\' Action58))> */
 		func() bool {
-			position123, tokenIndex123, depth123 := position, tokenIndex, depth
+			position186, tokenIndex186, depth186 := position, tokenIndex, depth
 			{
-				position124 := position
+				position187 := position
 				depth++
 				{
-					position125, tokenIndex125, depth125 := position, tokenIndex, depth
+					position188, tokenIndex188, depth188 := position, tokenIndex, depth
 					if buffer[position] != rune('\\') {
-						goto l126
+						goto l189
 					}
 					position++
 					{
-						position127, tokenIndex127, depth127 := position, tokenIndex, depth
+						position190, tokenIndex190, depth190 := position, tokenIndex, depth
 						if buffer[position] != rune('a') {
-							goto l128
+							goto l191
 						}
 						position++
-						goto l127
-					l128:
-						position, tokenIndex, depth = position127, tokenIndex127, depth127
+						goto l190
+					l191:
+						position, tokenIndex, depth = position190, tokenIndex190, depth190
 						if buffer[position] != rune('A') {
-							goto l126
+							goto l189
 						}
 						position++
 					}
-				l127:
-					if !rules[RuleAction30]() {
-						goto l126
+				l190:
+					if !rules[RuleAction43]() {
+						goto l189
 					}
-					goto l125
-				l126:
-					position, tokenIndex, depth = position125, tokenIndex125, depth125
+					goto l188
+				l189:
+					position, tokenIndex, depth = position188, tokenIndex188, depth188
 					if buffer[position] != rune('\\') {
-						goto l129
+						goto l192
 					}
 					position++
 					{
-						position130, tokenIndex130, depth130 := position, tokenIndex, depth
+						position193, tokenIndex193, depth193 := position, tokenIndex, depth
 						if buffer[position] != rune('b') {
-							goto l131
+							goto l194
 						}
 						position++
-						goto l130
-					l131:
-						position, tokenIndex, depth = position130, tokenIndex130, depth130
+						goto l193
+					l194:
+						position, tokenIndex, depth = position193, tokenIndex193, depth193
 						if buffer[position] != rune('B') {
-							goto l129
+							goto l192
 						}
 						position++
 					}
-				l130:
-					if !rules[RuleAction31]() {
-						goto l129
+				l193:
+					if !rules[RuleAction44]() {
+						goto l192
 					}
-					goto l125
-				l129:
-					position, tokenIndex, depth = position125, tokenIndex125, depth125
+					goto l188
+				l192:
+					position, tokenIndex, depth = position188, tokenIndex188, depth188
 					if buffer[position] != rune('\\') {
-						goto l132
+						goto l195
 					}
 					position++
 					{
-						position133, tokenIndex133, depth133 := position, tokenIndex, depth
+						position196, tokenIndex196, depth196 := position, tokenIndex, depth
 						if buffer[position] != rune('e') {
-							goto l134
+							goto l197
 						}
 						position++
-						goto l133
-					l134:
-						position, tokenIndex, depth = position133, tokenIndex133, depth133
+						goto l196
+					l197:
+						position, tokenIndex, depth = position196, tokenIndex196, depth196
 						if buffer[position] != rune('E') {
-							goto l132
+							goto l195
 						}
 						position++
 					}
-				l133:
-					if !rules[RuleAction32]() {
-						goto l132
+				l196:
+					if !rules[RuleAction45]() {
+						goto l195
 					}
-					goto l125
-				l132:
-					position, tokenIndex, depth = position125, tokenIndex125, depth125
+					goto l188
+				l195:
+					position, tokenIndex, depth = position188, tokenIndex188, depth188
 					if buffer[position] != rune('\\') {
-						goto l135
+						goto l198
 					}
 					position++
 					{
-						position136, tokenIndex136, depth136 := position, tokenIndex, depth
+						position199, tokenIndex199, depth199 := position, tokenIndex, depth
 						if buffer[position] != rune('f') {
-							goto l137
+							goto l200
 						}
 						position++
-						goto l136
-					l137:
-						position, tokenIndex, depth = position136, tokenIndex136, depth136
+						goto l199
+					l200:
+						position, tokenIndex, depth = position199, tokenIndex199, depth199
 						if buffer[position] != rune('F') {
-							goto l135
+							goto l198
 						}
 						position++
 					}
-				l136:
-					if !rules[RuleAction33]() {
-						goto l135
+				l199:
+					if !rules[RuleAction46]() {
+						goto l198
 					}
-					goto l125
-				l135:
-					position, tokenIndex, depth = position125, tokenIndex125, depth125
+					goto l188
+				l198:
+					position, tokenIndex, depth = position188, tokenIndex188, depth188
 					if buffer[position] != rune('\\') {
-						goto l138
+						goto l201
 					}
 					position++
 					{
-						position139, tokenIndex139, depth139 := position, tokenIndex, depth
+						position202, tokenIndex202, depth202 := position, tokenIndex, depth
 						if buffer[position] != rune('n') {
-							goto l140
+							goto l203
 						}
 						position++
-						goto l139
-					l140:
-						position, tokenIndex, depth = position139, tokenIndex139, depth139
+						goto l202
+					l203:
+						position, tokenIndex, depth = position202, tokenIndex202, depth202
 						if buffer[position] != rune('N') {
-							goto l138
+							goto l201
 						}
 						position++
 					}
-				l139:
-					if !rules[RuleAction34]() {
-						goto l138
+				l202:
+					if !rules[RuleAction47]() {
+						goto l201
 					}
-					goto l125
-				l138:
-					position, tokenIndex, depth = position125, tokenIndex125, depth125
+					goto l188
+				l201:
+					position, tokenIndex, depth = position188, tokenIndex188, depth188
 					if buffer[position] != rune('\\') {
-						goto l141
+						goto l204
 					}
 					position++
 					{
-						position142, tokenIndex142, depth142 := position, tokenIndex, depth
+						position205, tokenIndex205, depth205 := position, tokenIndex, depth
 						if buffer[position] != rune('r') {
-							goto l143
+							goto l206
 						}
 						position++
-						goto l142
-					l143:
-						position, tokenIndex, depth = position142, tokenIndex142, depth142
+						goto l205
+					l206:
+						position, tokenIndex, depth = position205, tokenIndex205, depth205
 						if buffer[position] != rune('R') {
-							goto l141
+							goto l204
 						}
 						position++
 					}
-				l142:
-					if !rules[RuleAction35]() {
-						goto l141
+				l205:
+					if !rules[RuleAction48]() {
+						goto l204
 					}
-					goto l125
-				l141:
-					position, tokenIndex, depth = position125, tokenIndex125, depth125
+					goto l188
+				l204:
+					position, tokenIndex, depth = position188, tokenIndex188, depth188
 					if buffer[position] != rune('\\') {
-						goto l144
+						goto l207
 					}
 					position++
 					{
-						position145, tokenIndex145, depth145 := position, tokenIndex, depth
+						position208, tokenIndex208, depth208 := position, tokenIndex, depth
 						if buffer[position] != rune('t') {
-							goto l146
+							goto l209
 						}
 						position++
-						goto l145
-					l146:
-						position, tokenIndex, depth = position145, tokenIndex145, depth145
+						goto l208
+					l209:
+						position, tokenIndex, depth = position208, tokenIndex208, depth208
 						if buffer[position] != rune('T') {
-							goto l144
+							goto l207
 						}
 						position++
 					}
-				l145:
-					if !rules[RuleAction36]() {
-						goto l144
+				l208:
+					if !rules[RuleAction49]() {
+						goto l207
 					}
-					goto l125
-				l144:
-					position, tokenIndex, depth = position125, tokenIndex125, depth125
+					goto l188
+				l207:
+					position, tokenIndex, depth = position188, tokenIndex188, depth188
 					if buffer[position] != rune('\\') {
-						goto l147
+						goto l210
 					}
 					position++
 					{
-						position148, tokenIndex148, depth148 := position, tokenIndex, depth
+						position211, tokenIndex211, depth211 := position, tokenIndex, depth
 						if buffer[position] != rune('v') {
-							goto l149
+							goto l212
 						}
 						position++
-						goto l148
-					l149:
-						position, tokenIndex, depth = position148, tokenIndex148, depth148
+						goto l211
+					l212:
+						position, tokenIndex, depth = position211, tokenIndex211, depth211
 						if buffer[position] != rune('V') {
-							goto l147
+							goto l210
 						}
 						position++
 					}
-				l148:
-					if !rules[RuleAction37]() {
-						goto l147
+				l211:
+					if !rules[RuleAction50]() {
+						goto l210
 					}
-					goto l125
-				l147:
-					position, tokenIndex, depth = position125, tokenIndex125, depth125
+					goto l188
+				l210:
+					position, tokenIndex, depth = position188, tokenIndex188, depth188
 					if buffer[position] != rune('\\') {
-						goto l150
+						goto l213
 					}
 					position++
 					if buffer[position] != rune('\'') {
-						goto l150
+						goto l213
 					}
 					position++
-					if !rules[RuleAction38]() {
-						goto l150
+					if !rules[RuleAction51]() {
+						goto l213
 					}
-					goto l125
-				l150:
-					position, tokenIndex, depth = position125, tokenIndex125, depth125
+					goto l188
+				l213:
+					position, tokenIndex, depth = position188, tokenIndex188, depth188
 					if buffer[position] != rune('\\') {
-						goto l151
+						goto l214
 					}
 					position++
 					if buffer[position] != rune('"') {
-						goto l151
+						goto l214
 					}
 					position++
-					if !rules[RuleAction39]() {
-						goto l151
+					if !rules[RuleAction52]() {
+						goto l214
 					}
-					goto l125
-				l151:
-					position, tokenIndex, depth = position125, tokenIndex125, depth125
+					goto l188
+				l214:
+					position, tokenIndex, depth = position188, tokenIndex188, depth188
 					if buffer[position] != rune('\\') {
-						goto l152
+						goto l215
 					}
 					position++
 					if buffer[position] != rune('[') {
-						goto l152
+						goto l215
 					}
 					position++
-					if !rules[RuleAction40]() {
-						goto l152
+					if !rules[RuleAction53]() {
+						goto l215
 					}
-					goto l125
-				l152:
-					position, tokenIndex, depth = position125, tokenIndex125, depth125
+					goto l188
+				l215:
+					position, tokenIndex, depth = position188, tokenIndex188, depth188
 					if buffer[position] != rune('\\') {
-						goto l153
+						goto l216
 					}
 					position++
 					if buffer[position] != rune(']') {
-						goto l153
+						goto l216
 					}
 					position++
-					if !rules[RuleAction41]() {
-						goto l153
+					if !rules[RuleAction54]() {
+						goto l216
 					}
-					goto l125
-				l153:
-					position, tokenIndex, depth = position125, tokenIndex125, depth125
+					goto l188
+				l216:
+					position, tokenIndex, depth = position188, tokenIndex188, depth188
 					if buffer[position] != rune('\\') {
-						goto l154
+						goto l217
 					}
 					position++
 					if buffer[position] != rune('-') {
-						goto l154
+						goto l217
 					}
 					position++
-					if !rules[RuleAction42]() {
-						goto l154
+					if !rules[RuleAction55]() {
+						goto l217
 					}
-					goto l125
-				l154:
-					position, tokenIndex, depth = position125, tokenIndex125, depth125
+					goto l188
+				l217:
+					position, tokenIndex, depth = position188, tokenIndex188, depth188
 					if buffer[position] != rune('\\') {
-						goto l155
+						goto l218
 					}
 					position++
 					{
-						position156 := position
+						position219 := position
 						depth++
 						if c := buffer[position]; c < rune('0') || c > rune('3') {
-							goto l155
+							goto l218
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('7') {
-							goto l155
+							goto l218
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('7') {
-							goto l155
+							goto l218
 						}
 						position++
 						depth--
-						add(RulePegText, position156)
+						add(RulePegText, position219)
 					}
-					if !rules[RuleAction43]() {
-						goto l155
+					if !rules[RuleAction56]() {
+						goto l218
 					}
-					goto l125
-				l155:
-					position, tokenIndex, depth = position125, tokenIndex125, depth125
+					goto l188
+				l218:
+					position, tokenIndex, depth = position188, tokenIndex188, depth188
 					if buffer[position] != rune('\\') {
-						goto l157
+						goto l220
 					}
 					position++
 					{
-						position158 := position
+						position221 := position
 						depth++
 						if c := buffer[position]; c < rune('0') || c > rune('7') {
-							goto l157
+							goto l220
 						}
 						position++
 						{
-							position159, tokenIndex159, depth159 := position, tokenIndex, depth
+							position222, tokenIndex222, depth222 := position, tokenIndex, depth
 							if c := buffer[position]; c < rune('0') || c > rune('7') {
-								goto l159
+								goto l222
 							}
 							position++
-							goto l160
-						l159:
-							position, tokenIndex, depth = position159, tokenIndex159, depth159
+							goto l223
+						l222:
+							position, tokenIndex, depth = position222, tokenIndex222, depth222
 						}
-					l160:
+					l223:
 						depth--
-						add(RulePegText, position158)
+						add(RulePegText, position221)
 					}
-					if !rules[RuleAction44]() {
-						goto l157
+					if !rules[RuleAction57]() {
+						goto l220
 					}
-					goto l125
-				l157:
-					position, tokenIndex, depth = position125, tokenIndex125, depth125
+					goto l188
+				l220:
+					position, tokenIndex, depth = position188, tokenIndex188, depth188
 					if buffer[position] != rune('\\') {
-						goto l123
+						goto l186
 					}
 					position++
 					if buffer[position] != rune('\\') {
-						goto l123
+						goto l186
 					}
 					position++
-					if !rules[RuleAction45]() {
-						goto l123
+					if !rules[RuleAction58]() {
+						goto l186
 					}
 				}
-			l125:
+			l188:
 				depth--
-				add(RuleEscape, position124)
+				add(RuleEscape, position187)
 			}
 			return true
-		l123:
-			position, tokenIndex, depth = position123, tokenIndex123, depth123
+		l186:
+			position, tokenIndex, depth = position186, tokenIndex186, depth186
 			return false
 		},
-		/* 19 LeftArrow <- <('<' '-' Spacing)> */
+		/* 26 LeftArrow <- <('<' '-' Spacing)> */
 		func() bool {
-			position161, tokenIndex161, depth161 := position, tokenIndex, depth
+			position224, tokenIndex224, depth224 := position, tokenIndex, depth
 			{
-				position162 := position
+				position225 := position
 				depth++
 				if buffer[position] != rune('<') {
-					goto l161
+					goto l224
 				}
 				position++
 				if buffer[position] != rune('-') {
-					goto l161
+					goto l224
 				}
 				position++
 				if !rules[RuleSpacing]() {
-					goto l161
+					goto l224
 				}
 				depth--
-				add(RuleLeftArrow, position162)
+				add(RuleLeftArrow, position225)
 			}
 			return true
-		l161:
-			position, tokenIndex, depth = position161, tokenIndex161, depth161
+		l224:
+			position, tokenIndex, depth = position224, tokenIndex224, depth224
 			return false
 		},
-		/* 20 Slash <- <('/' Spacing)> */
+		/* 27 Slash <- <('/' Spacing)> */
 		func() bool {
-			position163, tokenIndex163, depth163 := position, tokenIndex, depth
+			position226, tokenIndex226, depth226 := position, tokenIndex, depth
 			{
-				position164 := position
+				position227 := position
 				depth++
 				if buffer[position] != rune('/') {
-					goto l163
+					goto l226
 				}
 				position++
 				if !rules[RuleSpacing]() {
-					goto l163
+					goto l226
 				}
 				depth--
-				add(RuleSlash, position164)
+				add(RuleSlash, position227)
 			}
 			return true
-		l163:
-			position, tokenIndex, depth = position163, tokenIndex163, depth163
+		l226:
+			position, tokenIndex, depth = position226, tokenIndex226, depth226
 			return false
 		},
-		/* 21 And <- <('&' Spacing)> */
+		/* 28 And <- <('&' Spacing)> */
 		func() bool {
-			position165, tokenIndex165, depth165 := position, tokenIndex, depth
+			position228, tokenIndex228, depth228 := position, tokenIndex, depth
 			{
-				position166 := position
+				position229 := position
 				depth++
 				if buffer[position] != rune('&') {
-					goto l165
+					goto l228
 				}
 				position++
 				if !rules[RuleSpacing]() {
-					goto l165
+					goto l228
 				}
 				depth--
-				add(RuleAnd, position166)
+				add(RuleAnd, position229)
 			}
 			return true
-		l165:
-			position, tokenIndex, depth = position165, tokenIndex165, depth165
+		l228:
+			position, tokenIndex, depth = position228, tokenIndex228, depth228
 			return false
 		},
-		/* 22 Not <- <('!' Spacing)> */
+		/* 29 Not <- <('!' Spacing)> */
 		func() bool {
-			position167, tokenIndex167, depth167 := position, tokenIndex, depth
+			position230, tokenIndex230, depth230 := position, tokenIndex, depth
 			{
-				position168 := position
+				position231 := position
 				depth++
 				if buffer[position] != rune('!') {
-					goto l167
+					goto l230
 				}
 				position++
 				if !rules[RuleSpacing]() {
-					goto l167
+					goto l230
 				}
 				depth--
-				add(RuleNot, position168)
+				add(RuleNot, position231)
 			}
 			return true
-		l167:
-			position, tokenIndex, depth = position167, tokenIndex167, depth167
+		l230:
+			position, tokenIndex, depth = position230, tokenIndex230, depth230
 			return false
 		},
-		/* 23 Question <- <('?' Spacing)> */
+		/* 30 Question <- <('?' Spacing)> */
 		func() bool {
-			position169, tokenIndex169, depth169 := position, tokenIndex, depth
+			position232, tokenIndex232, depth232 := position, tokenIndex, depth
 			{
-				position170 := position
+				position233 := position
 				depth++
 				if buffer[position] != rune('?') {
-					goto l169
+					goto l232
 				}
 				position++
 				if !rules[RuleSpacing]() {
-					goto l169
+					goto l232
 				}
 				depth--
-				add(RuleQuestion, position170)
+				add(RuleQuestion, position233)
 			}
 			return true
-		l169:
-			position, tokenIndex, depth = position169, tokenIndex169, depth169
+		l232:
+			position, tokenIndex, depth = position232, tokenIndex232, depth232
 			return false
 		},
-		/* 24 Star <- <('*' Spacing)> */
+		/* 31 Star <- <('*' Spacing)> */
 		func() bool {
-			position171, tokenIndex171, depth171 := position, tokenIndex, depth
+			position234, tokenIndex234, depth234 := position, tokenIndex, depth
 			{
-				position172 := position
+				position235 := position
 				depth++
 				if buffer[position] != rune('*') {
-					goto l171
+					goto l234
 				}
 				position++
 				if !rules[RuleSpacing]() {
-					goto l171
+					goto l234
 				}
 				depth--
-				add(RuleStar, position172)
+				add(RuleStar, position235)
 			}
 			return true
-		l171:
-			position, tokenIndex, depth = position171, tokenIndex171, depth171
+		l234:
+			position, tokenIndex, depth = position234, tokenIndex234, depth234
 			return false
 		},
-		/* 25 Plus <- <('+' Spacing)> */
+		/* 32 Plus <- <('+' Spacing)> */
 		func() bool {
-			position173, tokenIndex173, depth173 := position, tokenIndex, depth
+			position236, tokenIndex236, depth236 := position, tokenIndex, depth
 			{
-				position174 := position
+				position237 := position
 				depth++
 				if buffer[position] != rune('+') {
-					goto l173
+					goto l236
+				}
+				position++
+				if !rules[RuleSpacing]() {
+					goto l236
+				}
+				depth--
+				add(RulePlus, position237)
+			}
+			return true
+		l236:
+			position, tokenIndex, depth = position236, tokenIndex236, depth236
+			return false
+		},
+		/* 33 Repetition <- <('{' <([0-9]+ (',' [0-9]*)?)> '}' Spacing)> */
+		func() bool {
+			position238, tokenIndex238, depth238 := position, tokenIndex, depth
+			{
+				position239 := position
+				depth++
+				if buffer[position] != rune('{') {
+					goto l238
+				}
+				position++
+				{
+					position240 := position
+					depth++
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l238
+					}
+					position++
+				l241:
+					{
+						position242, tokenIndex242, depth242 := position, tokenIndex, depth
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l242
+						}
+						position++
+						goto l241
+					l242:
+						position, tokenIndex, depth = position242, tokenIndex242, depth242
+					}
+					{
+						position243, tokenIndex243, depth243 := position, tokenIndex, depth
+						if buffer[position] != rune(',') {
+							goto l243
+						}
+						position++
+					l245:
+						{
+							position246, tokenIndex246, depth246 := position, tokenIndex, depth
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l246
+							}
+							position++
+							goto l245
+						l246:
+							position, tokenIndex, depth = position246, tokenIndex246, depth246
+						}
+						goto l244
+					l243:
+						position, tokenIndex, depth = position243, tokenIndex243, depth243
+					}
+				l244:
+					depth--
+					add(RulePegText, position240)
+				}
+				if buffer[position] != rune('}') {
+					goto l238
 				}
 				position++
 				if !rules[RuleSpacing]() {
-					goto l173
+					goto l238
 				}
 				depth--
-				add(RulePlus, position174)
+				add(RuleRepetition, position239)
 			}
 			return true
-		l173:
-			position, tokenIndex, depth = position173, tokenIndex173, depth173
+		l238:
+			position, tokenIndex, depth = position238, tokenIndex238, depth238
 			return false
 		},
-		/* 26 Open <- <('(' Spacing)> */
+		/* 34 Open <- <('(' Spacing)> */
 		func() bool {
-			position175, tokenIndex175, depth175 := position, tokenIndex, depth
+			position247, tokenIndex247, depth247 := position, tokenIndex, depth
 			{
-				position176 := position
+				position248 := position
 				depth++
 				if buffer[position] != rune('(') {
-					goto l175
+					goto l247
 				}
 				position++
 				if !rules[RuleSpacing]() {
-					goto l175
+					goto l247
 				}
 				depth--
-				add(RuleOpen, position176)
+				add(RuleOpen, position248)
 			}
 			return true
-		l175:
-			position, tokenIndex, depth = position175, tokenIndex175, depth175
+		l247:
+			position, tokenIndex, depth = position247, tokenIndex247, depth247
 			return false
 		},
-		/* 27 Close <- <(')' Spacing)> */
+		/* 35 Close <- <(')' Spacing)> */
 		func() bool {
-			position177, tokenIndex177, depth177 := position, tokenIndex, depth
+			position249, tokenIndex249, depth249 := position, tokenIndex, depth
 			{
-				position178 := position
+				position250 := position
 				depth++
 				if buffer[position] != rune(')') {
-					goto l177
+					goto l249
 				}
 				position++
 				if !rules[RuleSpacing]() {
-					goto l177
+					goto l249
 				}
 				depth--
-				add(RuleClose, position178)
+				add(RuleClose, position250)
 			}
 			return true
-		l177:
-			position, tokenIndex, depth = position177, tokenIndex177, depth177
+		l249:
+			position, tokenIndex, depth = position249, tokenIndex249, depth249
 			return false
 		},
-		/* 28 Dot <- <('.' Spacing)> */
+		/* 36 Dot <- <('.' Spacing)> */
 		func() bool {
-			position179, tokenIndex179, depth179 := position, tokenIndex, depth
+			position251, tokenIndex251, depth251 := position, tokenIndex, depth
 			{
-				position180 := position
+				position252 := position
 				depth++
 				if buffer[position] != rune('.') {
-					goto l179
+					goto l251
+				}
+				position++
+				if !rules[RuleSpacing]() {
+					goto l251
+				}
+				depth--
+				add(RuleDot, position252)
+			}
+			return true
+		l251:
+			position, tokenIndex, depth = position251, tokenIndex251, depth251
+			return false
+		},
+		/* 37 Colon <- <(':' Spacing)> */
+		func() bool {
+			position253, tokenIndex253, depth253 := position, tokenIndex, depth
+			{
+				position254 := position
+				depth++
+				if buffer[position] != rune(':') {
+					goto l253
+				}
+				position++
+				if !rules[RuleSpacing]() {
+					goto l253
+				}
+				depth--
+				add(RuleColon, position254)
+			}
+			return true
+		l253:
+			position, tokenIndex, depth = position253, tokenIndex253, depth253
+			return false
+		},
+		/* 38 Associativity <- <(<(('l' 'e' 'f' 't') / ('r' 'i' 'g' 'h' 't'))> !IdentCont Spacing)> */
+		func() bool {
+			position255, tokenIndex255, depth255 := position, tokenIndex, depth
+			{
+				position256 := position
+				depth++
+				{
+					position257 := position
+					depth++
+					{
+						position258, tokenIndex258, depth258 := position, tokenIndex, depth
+						if buffer[position] != rune('l') {
+							goto l259
+						}
+						position++
+						if buffer[position] != rune('e') {
+							goto l259
+						}
+						position++
+						if buffer[position] != rune('f') {
+							goto l259
+						}
+						position++
+						if buffer[position] != rune('t') {
+							goto l259
+						}
+						position++
+						goto l258
+					l259:
+						position, tokenIndex, depth = position258, tokenIndex258, depth258
+						if buffer[position] != rune('r') {
+							goto l255
+						}
+						position++
+						if buffer[position] != rune('i') {
+							goto l255
+						}
+						position++
+						if buffer[position] != rune('g') {
+							goto l255
+						}
+						position++
+						if buffer[position] != rune('h') {
+							goto l255
+						}
+						position++
+						if buffer[position] != rune('t') {
+							goto l255
+						}
+						position++
+					}
+				l258:
+					depth--
+					add(RulePegText, position257)
+				}
+				{
+					position260, tokenIndex260, depth260 := position, tokenIndex, depth
+					if !rules[RuleIdentCont]() {
+						goto l260
+					}
+					goto l255
+				l260:
+					position, tokenIndex, depth = position260, tokenIndex260, depth260
+				}
+				if !rules[RuleSpacing]() {
+					goto l255
+				}
+				depth--
+				add(RuleAssociativity, position256)
+			}
+			return true
+		l255:
+			position, tokenIndex, depth = position255, tokenIndex255, depth255
+			return false
+		},
+		/* 39 Commit <- <('^' Spacing)> */
+		func() bool {
+			position261, tokenIndex261, depth261 := position, tokenIndex, depth
+			{
+				position262 := position
+				depth++
+				if buffer[position] != rune('^') {
+					goto l261
 				}
 				position++
 				if !rules[RuleSpacing]() {
-					goto l179
+					goto l261
 				}
 				depth--
-				add(RuleDot, position180)
+				add(RuleCommit, position262)
 			}
 			return true
-		l179:
-			position, tokenIndex, depth = position179, tokenIndex179, depth179
+		l261:
+			position, tokenIndex, depth = position261, tokenIndex261, depth261
 			return false
 		},
-		/* 29 Spacing <- <(Space / Comment)*> */
+		/* 40 Spacing <- <(Space / Comment)*> */
 		func() bool {
 			{
-				position182 := position
+				position264 := position
 				depth++
-			l183:
+			l265:
 				{
-					position184, tokenIndex184, depth184 := position, tokenIndex, depth
+					position266, tokenIndex266, depth266 := position, tokenIndex, depth
 					{
-						position185, tokenIndex185, depth185 := position, tokenIndex, depth
+						position267, tokenIndex267, depth267 := position, tokenIndex, depth
 						if !rules[RuleSpace]() {
-							goto l186
+							goto l268
 						}
-						goto l185
-					l186:
-						position, tokenIndex, depth = position185, tokenIndex185, depth185
+						goto l267
+					l268:
+						position, tokenIndex, depth = position267, tokenIndex267, depth267
 						if !rules[RuleComment]() {
-							goto l184
+							goto l266
 						}
 					}
-				l185:
-					goto l183
-				l184:
-					position, tokenIndex, depth = position184, tokenIndex184, depth184
+				l267:
+					goto l265
+				l266:
+					position, tokenIndex, depth = position266, tokenIndex266, depth266
 				}
 				depth--
-				add(RuleSpacing, position182)
+				add(RuleSpacing, position264)
 			}
 			return true
 		},
-		/* 30 Comment <- <('#' (!EndOfLine .)* EndOfLine)> */
+		/* 41 Comment <- <('#' (!EndOfLine .)* EndOfLine)> */
 		func() bool {
-			position187, tokenIndex187, depth187 := position, tokenIndex, depth
+			position269, tokenIndex269, depth269 := position, tokenIndex, depth
 			{
-				position188 := position
+				position270 := position
 				depth++
 				if buffer[position] != rune('#') {
-					goto l187
+					goto l269
 				}
 				position++
-			l189:
+			l271:
 				{
-					position190, tokenIndex190, depth190 := position, tokenIndex, depth
+					position272, tokenIndex272, depth272 := position, tokenIndex, depth
 					{
-						position191, tokenIndex191, depth191 := position, tokenIndex, depth
+						position273, tokenIndex273, depth273 := position, tokenIndex, depth
 						if !rules[RuleEndOfLine]() {
-							goto l191
+							goto l273
 						}
-						goto l190
-					l191:
-						position, tokenIndex, depth = position191, tokenIndex191, depth191
+						goto l272
+					l273:
+						position, tokenIndex, depth = position273, tokenIndex273, depth273
 					}
 					if !matchDot() {
-						goto l190
+						goto l272
 					}
-					goto l189
-				l190:
-					position, tokenIndex, depth = position190, tokenIndex190, depth190
+					goto l271
+				l272:
+					position, tokenIndex, depth = position272, tokenIndex272, depth272
 				}
 				if !rules[RuleEndOfLine]() {
-					goto l187
+					goto l269
 				}
 				depth--
-				add(RuleComment, position188)
+				add(RuleComment, position270)
 			}
 			return true
-		l187:
-			position, tokenIndex, depth = position187, tokenIndex187, depth187
+		l269:
+			position, tokenIndex, depth = position269, tokenIndex269, depth269
 			return false
 		},
-		/* 31 Space <- <(' ' / '\t' / EndOfLine)> */
+		/* 42 Space <- <(' ' / '\t' / EndOfLine)> */
 		func() bool {
-			position192, tokenIndex192, depth192 := position, tokenIndex, depth
+			position274, tokenIndex274, depth274 := position, tokenIndex, depth
 			{
-				position193 := position
+				position275 := position
 				depth++
 				{
-					position194, tokenIndex194, depth194 := position, tokenIndex, depth
+					position276, tokenIndex276, depth276 := position, tokenIndex, depth
 					if buffer[position] != rune(' ') {
-						goto l195
+						goto l277
 					}
 					position++
-					goto l194
-				l195:
-					position, tokenIndex, depth = position194, tokenIndex194, depth194
+					goto l276
+				l277:
+					position, tokenIndex, depth = position276, tokenIndex276, depth276
 					if buffer[position] != rune('\t') {
-						goto l196
+						goto l278
 					}
 					position++
-					goto l194
-				l196:
-					position, tokenIndex, depth = position194, tokenIndex194, depth194
+					goto l276
+				l278:
+					position, tokenIndex, depth = position276, tokenIndex276, depth276
 					if !rules[RuleEndOfLine]() {
-						goto l192
+						goto l274
 					}
 				}
-			l194:
+			l276:
 				depth--
-				add(RuleSpace, position193)
+				add(RuleSpace, position275)
 			}
 			return true
-		l192:
-			position, tokenIndex, depth = position192, tokenIndex192, depth192
+		l274:
+			position, tokenIndex, depth = position274, tokenIndex274, depth274
 			return false
 		},
-		/* 32 EndOfLine <- <(('\r' '\n') / '\n' / '\r')> */
+		/* 43 EndOfLine <- <(('\r' '\n') / '\n' / '\r')> */
 		func() bool {
-			position197, tokenIndex197, depth197 := position, tokenIndex, depth
+			position279, tokenIndex279, depth279 := position, tokenIndex, depth
 			{
-				position198 := position
+				position280 := position
 				depth++
 				{
-					position199, tokenIndex199, depth199 := position, tokenIndex, depth
+					position281, tokenIndex281, depth281 := position, tokenIndex, depth
 					if buffer[position] != rune('\r') {
-						goto l200
+						goto l282
 					}
 					position++
 					if buffer[position] != rune('\n') {
-						goto l200
+						goto l282
 					}
 					position++
-					goto l199
-				l200:
-					position, tokenIndex, depth = position199, tokenIndex199, depth199
+					goto l281
+				l282:
+					position, tokenIndex, depth = position281, tokenIndex281, depth281
 					if buffer[position] != rune('\n') {
-						goto l201
+						goto l283
 					}
 					position++
-					goto l199
-				l201:
-					position, tokenIndex, depth = position199, tokenIndex199, depth199
+					goto l281
+				l283:
+					position, tokenIndex, depth = position281, tokenIndex281, depth281
 					if buffer[position] != rune('\r') {
-						goto l197
+						goto l279
 					}
 					position++
 				}
-			l199:
+			l281:
 				depth--
-				add(RuleEndOfLine, position198)
+				add(RuleEndOfLine, position280)
 			}
 			return true
-		l197:
-			position, tokenIndex, depth = position197, tokenIndex197, depth197
+		l279:
+			position, tokenIndex, depth = position279, tokenIndex279, depth279
 			return false
 		},
-		/* 33 EndOfFile <- <!.> */
+		/* 44 EndOfFile <- <!.> */
 		func() bool {
-			position202, tokenIndex202, depth202 := position, tokenIndex, depth
+			position284, tokenIndex284, depth284 := position, tokenIndex, depth
 			{
-				position203 := position
+				position285 := position
 				depth++
 				{
-					position204, tokenIndex204, depth204 := position, tokenIndex, depth
+					position286, tokenIndex286, depth286 := position, tokenIndex, depth
 					if !matchDot() {
-						goto l204
+						goto l286
 					}
-					goto l202
-				l204:
-					position, tokenIndex, depth = position204, tokenIndex204, depth204
+					goto l284
+				l286:
+					position, tokenIndex, depth = position286, tokenIndex286, depth286
 				}
 				depth--
-				add(RuleEndOfFile, position203)
+				add(RuleEndOfFile, position285)
 			}
 			return true
-		l202:
-			position, tokenIndex, depth = position202, tokenIndex202, depth202
+		l284:
+			position, tokenIndex, depth = position284, tokenIndex284, depth284
 			return false
 		},
-		/* 34 Action <- <('{' <ActionInner> '}' Spacing)> */
+		/* 45 Action <- <('{' <ActionInner> '}' Spacing)> */
 		func() bool {
-			position205, tokenIndex205, depth205 := position, tokenIndex, depth
+			position287, tokenIndex287, depth287 := position, tokenIndex, depth
 			{
-				position206 := position
+				position288 := position
 				depth++
 				if buffer[position] != rune('{') {
-					goto l205
+					goto l287
 				}
 				position++
 				{
-					position207 := position
+					position289 := position
 					depth++
 					if !rules[RuleActionInner]() {
-						goto l205
+						goto l287
 					}
 					depth--
-					add(RulePegText, position207)
+					add(RulePegText, position289)
 				}
 				if buffer[position] != rune('}') {
-					goto l205
+					goto l287
 				}
 				position++
 				if !rules[RuleSpacing]() {
-					goto l205
+					goto l287
 				}
 				depth--
-				add(RuleAction, position206)
+				add(RuleAction, position288)
 			}
 			return true
-		l205:
-			position, tokenIndex, depth = position205, tokenIndex205, depth205
+		l287:
+			position, tokenIndex, depth = position287, tokenIndex287, depth287
 			return false
 		},
-		/* 35 ActionInner <- <((!('{' / '}') .)* ('{' ActionInner '}' (!('{' / '}') .)*)*)> */
+		/* 46 ActionInner <- <((!('{' / '}') .)* ('{' ActionInner '}' (!('{' / '}') .)*)*)> */
 		func() bool {
 			{
-				position209 := position
+				position291 := position
 				depth++
-			l210:
+			l292:
 				{
-					position211, tokenIndex211, depth211 := position, tokenIndex, depth
+					position293, tokenIndex293, depth293 := position, tokenIndex, depth
 					{
-						position212, tokenIndex212, depth212 := position, tokenIndex, depth
+						position294, tokenIndex294, depth294 := position, tokenIndex, depth
 						{
-							position213, tokenIndex213, depth213 := position, tokenIndex, depth
+							position295, tokenIndex295, depth295 := position, tokenIndex, depth
 							if buffer[position] != rune('{') {
-								goto l214
+								goto l296
 							}
 							position++
-							goto l213
-						l214:
-							position, tokenIndex, depth = position213, tokenIndex213, depth213
+							goto l295
+						l296:
+							position, tokenIndex, depth = position295, tokenIndex295, depth295
 							if buffer[position] != rune('}') {
-								goto l212
+								goto l294
 							}
 							position++
 						}
-					l213:
-						goto l211
-					l212:
-						position, tokenIndex, depth = position212, tokenIndex212, depth212
+					l295:
+						goto l293
+					l294:
+						position, tokenIndex, depth = position294, tokenIndex294, depth294
 					}
 					if !matchDot() {
-						goto l211
+						goto l293
 					}
-					goto l210
-				l211:
-					position, tokenIndex, depth = position211, tokenIndex211, depth211
+					goto l292
+				l293:
+					position, tokenIndex, depth = position293, tokenIndex293, depth293
 				}
-			l215:
+			l297:
 				{
-					position216, tokenIndex216, depth216 := position, tokenIndex, depth
+					position298, tokenIndex298, depth298 := position, tokenIndex, depth
 					if buffer[position] != rune('{') {
-						goto l216
+						goto l298
 					}
 					position++
 					if !rules[RuleActionInner]() {
-						goto l216
+						goto l298
 					}
 					if buffer[position] != rune('}') {
-						goto l216
+						goto l298
 					}
 					position++
-				l217:
+				l299:
 					{
-						position218, tokenIndex218, depth218 := position, tokenIndex, depth
+						position300, tokenIndex300, depth300 := position, tokenIndex, depth
 						{
-							position219, tokenIndex219, depth219 := position, tokenIndex, depth
+							position301, tokenIndex301, depth301 := position, tokenIndex, depth
 							{
-								position220, tokenIndex220, depth220 := position, tokenIndex, depth
+								position302, tokenIndex302, depth302 := position, tokenIndex, depth
 								if buffer[position] != rune('{') {
-									goto l221
+									goto l303
 								}
 								position++
-								goto l220
-							l221:
-								position, tokenIndex, depth = position220, tokenIndex220, depth220
+								goto l302
+							l303:
+								position, tokenIndex, depth = position302, tokenIndex302, depth302
 								if buffer[position] != rune('}') {
-									goto l219
+									goto l301
 								}
 								position++
 							}
-						l220:
-							goto l218
-						l219:
-							position, tokenIndex, depth = position219, tokenIndex219, depth219
+						l302:
+							goto l300
+						l301:
+							position, tokenIndex, depth = position301, tokenIndex301, depth301
 						}
 						if !matchDot() {
-							goto l218
+							goto l300
 						}
-						goto l217
-					l218:
-						position, tokenIndex, depth = position218, tokenIndex218, depth218
+						goto l299
+					l300:
+						position, tokenIndex, depth = position300, tokenIndex300, depth300
 					}
-					goto l215
-				l216:
-					position, tokenIndex, depth = position216, tokenIndex216, depth216
+					goto l297
+				l298:
+					position, tokenIndex, depth = position298, tokenIndex298, depth298
 				}
 				depth--
-				add(RuleActionInner, position209)
+				add(RuleActionInner, position291)
 			}
 			return true
 		},
-		/* 36 Begin <- <('<' Spacing)> */
+		/* 47 Begin <- <('<' Spacing)> */
 		func() bool {
-			position222, tokenIndex222, depth222 := position, tokenIndex, depth
+			position304, tokenIndex304, depth304 := position, tokenIndex, depth
 			{
-				position223 := position
+				position305 := position
 				depth++
 				if buffer[position] != rune('<') {
-					goto l222
+					goto l304
 				}
 				position++
 				if !rules[RuleSpacing]() {
-					goto l222
+					goto l304
 				}
 				depth--
-				add(RuleBegin, position223)
+				add(RuleBegin, position305)
 			}
 			return true
-		l222:
-			position, tokenIndex, depth = position222, tokenIndex222, depth222
+		l304:
+			position, tokenIndex, depth = position304, tokenIndex304, depth304
 			return false
 		},
-		/* 37 End <- <('>' Spacing)> */
+		/* 48 End <- <('>' Spacing)> */
 		func() bool {
-			position224, tokenIndex224, depth224 := position, tokenIndex, depth
+			position306, tokenIndex306, depth306 := position, tokenIndex, depth
 			{
-				position225 := position
+				position307 := position
 				depth++
 				if buffer[position] != rune('>') {
-					goto l224
+					goto l306
 				}
 				position++
 				if !rules[RuleSpacing]() {
-					goto l224
+					goto l306
 				}
 				depth--
-				add(RuleEnd, position225)
+				add(RuleEnd, position307)
 			}
 			return true
-		l224:
-			position, tokenIndex, depth = position224, tokenIndex224, depth224
+		l306:
+			position, tokenIndex, depth = position306, tokenIndex306, depth306
 			return false
 		},
-		/* 39 Action0 <- <{ p.AddPackage(buffer[begin:end]) }> */
+		/* 50 Action0 <- <{ p.AddPackage(buffer[begin:end]) }> */
 		func() bool {
 			{
 				add(RuleAction0, position)
 			}
 			return true
 		},
-		/* 40 Action1 <- <{ p.AddPeg(buffer[begin:end]) }> */
+		/* 51 Action1 <- <{ p.AddPeg(buffer[begin:end]) }> */
 		func() bool {
 			{
 				add(RuleAction1, position)
 			}
 			return true
 		},
-		/* 41 Action2 <- <{ p.AddState(buffer[begin:end]) }> */
+		/* 52 Action2 <- <{ p.AddState(buffer[begin:end]) }> */
 		func() bool {
 			{
 				add(RuleAction2, position)
 			}
 			return true
 		},
-		/* 42 Action3 <- <{ p.AddRule(buffer[begin:end]) }> */
+		/* 53 Action3 <- <{ p.AddDirective(buffer[begin:end]) }> */
 		func() bool {
 			{
 				add(RuleAction3, position)
 			}
 			return true
 		},
-		/* 43 Action4 <- <{ p.AddExpression() }> */
+		/* 54 Action4 <- <{ p.AddDirectiveArgument(buffer[begin:end]) }> */
 		func() bool {
 			{
 				add(RuleAction4, position)
 			}
 			return true
 		},
-		/* 44 Action5 <- <{ p.AddAlternate() }> */
+		/* 55 Action5 <- <{ p.AddRule(buffer[begin:end]); p.Locate(begin, end) }> */
 		func() bool {
 			{
 				add(RuleAction5, position)
 			}
 			return true
 		},
-		/* 45 Action6 <- <{ p.AddNil(); p.AddAlternate() }> */
+		/* 56 Action6 <- <{ p.AddRule(buffer[begin:end]); p.Locate(begin, end); p.AddSuppressed() }> */
 		func() bool {
 			{
 				add(RuleAction6, position)
 			}
 			return true
 		},
-		/* 46 Action7 <- <{ p.AddNil() }> */
+		/* 57 Action7 <- <{ p.AddExpression() }> */
 		func() bool {
 			{
 				add(RuleAction7, position)
 			}
 			return true
 		},
-		/* 47 Action8 <- <{ p.AddSequence() }> */
+		/* 58 Action8 <- <{ p.AddValue(buffer[begin:end]) }> */
 		func() bool {
 			{
 				add(RuleAction8, position)
 			}
 			return true
 		},
-		/* 48 Action9 <- <{ p.AddPredicate(buffer[begin:end]) }> */
+		/* 59 Action9 <- <{ p.AddValueAction(buffer[begin:end]) }> */
 		func() bool {
 			{
 				add(RuleAction9, position)
 			}
 			return true
 		},
-		/* 49 Action10 <- <{ p.AddPeekFor() }> */
+		/* 60 Action10 <- <{ p.AddOperators(buffer[begin:end]) }> */
 		func() bool {
 			{
 				add(RuleAction10, position)
 			}
 			return true
 		},
-		/* 50 Action11 <- <{ p.AddPeekNot() }> */
+		/* 61 Action11 <- <{ p.AddPrecedence() }> */
 		func() bool {
 			{
 				add(RuleAction11, position)
 			}
 			return true
 		},
-		/* 51 Action12 <- <{ p.AddQuery() }> */
+		nil,
+		/* 63 Action12 <- <{ p.Locate(begin, end) }> */
 		func() bool {
 			{
 				add(RuleAction12, position)
 			}
 			return true
 		},
-		/* 52 Action13 <- <{ p.AddStar() }> */
+		/* 64 Action13 <- <{ p.Locate(begin, end); p.AddAlternate() }> */
 		func() bool {
 			{
 				add(RuleAction13, position)
 			}
 			return true
 		},
-		/* 53 Action14 <- <{ p.AddPlus() }> */
+		/* 65 Action14 <- <{ p.AddNil(); p.AddAlternate() }> */
 		func() bool {
 			{
 				add(RuleAction14, position)
 			}
 			return true
 		},
-		/* 54 Action15 <- <{ p.AddName(buffer[begin:end]) }> */
+		/* 66 Action15 <- <{ p.AddNil() }> */
 		func() bool {
 			{
 				add(RuleAction15, position)
 			}
 			return true
 		},
-		/* 55 Action16 <- <{ p.AddDot() }> */
+		/* 67 Action16 <- <{ p.AddSequence() }> */
 		func() bool {
 			{
 				add(RuleAction16, position)
 			}
 			return true
 		},
-		/* 56 Action17 <- <{ p.AddAction(buffer[begin:end]) }> */
+		/* 68 Action17 <- <{ p.AddLabel(buffer[begin:end]) }> */
 		func() bool {
 			{
 				add(RuleAction17, position)
 			}
 			return true
 		},
-		/* 57 Action18 <- <{ p.AddPush() }> */
+		/* 69 Action18 <- <{ p.AddLabeled() }> */
 		func() bool {
 			{
 				add(RuleAction18, position)
 			}
 			return true
 		},
-		nil,
-		/* 59 Action19 <- <{ p.AddSequence() }> */
+		/* 70 Action19 <- <{ p.AddPredicate(buffer[begin:end]) }> */
 		func() bool {
 			{
 				add(RuleAction19, position)
 			}
 			return true
 		},
-		/* 60 Action20 <- <{ p.AddSequence() }> */
+		/* 71 Action20 <- <{ p.AddPeekFor() }> */
 		func() bool {
 			{
 				add(RuleAction20, position)
 			}
 			return true
 		},
-		/* 61 Action21 <- <{ p.AddPeekNot(); p.AddDot(); p.AddSequence() }> */
+		/* 72 Action21 <- <{ p.AddPeekNot() }> */
 		func() bool {
 			{
 				add(RuleAction21, position)
 			}
 			return true
 		},
-		/* 62 Action22 <- <{ p.AddPeekNot(); p.AddDot(); p.AddSequence() }> */
+		/* 73 Action22 <- <{ p.AddQuery() }> */
 		func() bool {
 			{
 				add(RuleAction22, position)
 			}
 			return true
 		},
-		/* 63 Action23 <- <{ p.AddAlternate() }> */
+		/* 74 Action23 <- <{ p.AddStar() }> */
 		func() bool {
 			{
 				add(RuleAction23, position)
 			}
 			return true
 		},
-		/* 64 Action24 <- <{ p.AddAlternate() }> */
+		/* 75 Action24 <- <{ p.AddPlus() }> */
 		func() bool {
 			{
 				add(RuleAction24, position)
 			}
 			return true
 		},
-		/* 65 Action25 <- <{ p.AddRange() }> */
+		/* 76 Action25 <- <{ p.AddRepetition(buffer[begin:end]) }> */
 		func() bool {
 			{
 				add(RuleAction25, position)
 			}
 			return true
 		},
-		/* 66 Action26 <- <{ p.AddDoubleRange() }> */
+		/* 77 Action26 <- <{ p.AddName(buffer[begin:end]) }> */
 		func() bool {
 			{
 				add(RuleAction26, position)
 			}
 			return true
 		},
-		/* 67 Action27 <- <{ p.AddCharacter(buffer[begin:end]) }> */
+		/* 78 Action27 <- <{ p.AddDot() }> */
 		func() bool {
 			{
 				add(RuleAction27, position)
 			}
 			return true
 		},
-		/* 68 Action28 <- <{ p.AddDoubleCharacter(buffer[begin:end]) }> */
+		/* 79 Action28 <- <{ p.AddCommit() }> */
 		func() bool {
 			{
 				add(RuleAction28, position)
 			}
 			return true
 		},
-		/* 69 Action29 <- <{ p.AddCharacter(buffer[begin:end]) }> */
+		/* 80 Action29 <- <{ p.AddBackReference(buffer[begin:end]) }> */
 		func() bool {
 			{
 				add(RuleAction29, position)
 			}
 			return true
 		},
-		/* 70 Action30 <- <{ p.AddCharacter("\a") }> */
+		/* 81 Action30 <- <{ p.AddAction(buffer[begin:end]) }> */
 		func() bool {
 			{
 				add(RuleAction30, position)
 			}
 			return true
 		},
-		/* 71 Action31 <- <{ p.AddCharacter("\b") }> */
+		/* 82 Action31 <- <{ p.AddPush() }> */
 		func() bool {
 			{
 				add(RuleAction31, position)
 			}
 			return true
 		},
-		/* 72 Action32 <- <{ p.AddCharacter("\x1B") }> */
+		/* 83 Action32 <- <{ p.AddSequence() }> */
 		func() bool {
 			{
 				add(RuleAction32, position)
 			}
 			return true
 		},
-		/* 73 Action33 <- <{ p.AddCharacter("\f") }> */
+		/* 84 Action33 <- <{ p.AddSequence() }> */
 		func() bool {
 			{
 				add(RuleAction33, position)
 			}
 			return true
 		},
-		/* 74 Action34 <- <{ p.AddCharacter("\n") }> */
+		/* 85 Action34 <- <{ p.AddPeekNot(); p.AddDot(); p.AddSequence() }> */
 		func() bool {
 			{
 				add(RuleAction34, position)
 			}
 			return true
 		},
-		/* 75 Action35 <- <{ p.AddCharacter("\r") }> */
+		/* 86 Action35 <- <{ p.AddPeekNot(); p.AddDot(); p.AddSequence() }> */
 		func() bool {
 			{
 				add(RuleAction35, position)
 			}
 			return true
 		},
-		/* 76 Action36 <- <{ p.AddCharacter("\t") }> */
+		/* 87 Action36 <- <{ p.AddAlternate() }> */
 		func() bool {
 			{
 				add(RuleAction36, position)
 			}
 			return true
 		},
-		/* 77 Action37 <- <{ p.AddCharacter("\v") }> */
+		/* 88 Action37 <- <{ p.AddAlternate() }> */
 		func() bool {
 			{
 				add(RuleAction37, position)
 			}
 			return true
 		},
-		/* 78 Action38 <- <{ p.AddCharacter("'") }> */
+		/* 89 Action38 <- <{ p.AddRange() }> */
 		func() bool {
 			{
 				add(RuleAction38, position)
 			}
 			return true
 		},
-		/* 79 Action39 <- <{ p.AddCharacter("\"") }> */
+		/* 90 Action39 <- <{ p.AddDoubleRange() }> */
 		func() bool {
 			{
 				add(RuleAction39, position)
 			}
 			return true
 		},
-		/* 80 Action40 <- <{ p.AddCharacter("[") }> */
+		/* 91 Action40 <- <{ p.AddCharacter(buffer[begin:end]) }> */
 		func() bool {
 			{
 				add(RuleAction40, position)
 			}
 			return true
 		},
-		/* 81 Action41 <- <{ p.AddCharacter("]") }> */
+		/* 92 Action41 <- <{ p.AddDoubleCharacter(buffer[begin:end]) }> */
 		func() bool {
 			{
 				add(RuleAction41, position)
 			}
 			return true
 		},
-		/* 82 Action42 <- <{ p.AddCharacter("-") }> */
+		/* 93 Action42 <- <{ p.AddCharacter(buffer[begin:end]) }> */
 		func() bool {
 			{
 				add(RuleAction42, position)
 			}
 			return true
 		},
-		/* 83 Action43 <- <{ p.AddOctalCharacter(buffer[begin:end]) }> */
+		/* 94 Action43 <- <{ p.AddCharacter("\a") }> */
 		func() bool {
 			{
 				add(RuleAction43, position)
 			}
 			return true
 		},
-		/* 84 Action44 <- <{ p.AddOctalCharacter(buffer[begin:end]) }> */
+		/* 95 Action44 <- <{ p.AddCharacter("\b") }> */
 		func() bool {
 			{
 				add(RuleAction44, position)
 			}
 			return true
 		},
-		/* 85 Action45 <- <{ p.AddCharacter("\\") }> */
+		/* 96 Action45 <- <{ p.AddCharacter("\x1B") }> */
 		func() bool {
 			{
 				add(RuleAction45, position)
 			}
 			return true
 		},
+		/* 97 Action46 <- <{ p.AddCharacter("\f") }> */
+		func() bool {
+			{
+				add(RuleAction46, position)
+			}
+			return true
+		},
+		/* 98 Action47 <- <{ p.AddCharacter("\n") }> */
+		func() bool {
+			{
+				add(RuleAction47, position)
+			}
+			return true
+		},
+		/* 99 Action48 <- <{ p.AddCharacter("\r") }> */
+		func() bool {
+			{
+				add(RuleAction48, position)
+			}
+			return true
+		},
+		/* 100 Action49 <- <{ p.AddCharacter("\t") }> */
+		func() bool {
+			{
+				add(RuleAction49, position)
+			}
+			return true
+		},
+		/* 101 Action50 <- <{ p.AddCharacter("\v") }> */
+		func() bool {
+			{
+				add(RuleAction50, position)
+			}
+			return true
+		},
+		/* 102 Action51 <- <{ p.AddCharacter("'") }> */
+		func() bool {
+			{
+				add(RuleAction51, position)
+			}
+			return true
+		},
+		/* 103 Action52 <- <{ p.AddCharacter("\"") }> */
+		func() bool {
+			{
+				add(RuleAction52, position)
+			}
+			return true
+		},
+		/* 104 Action53 <- <{ p.AddCharacter("[") }> */
+		func() bool {
+			{
+				add(RuleAction53, position)
+			}
+			return true
+		},
+		/* 105 Action54 <- <{ p.AddCharacter("]") }> */
+		func() bool {
+			{
+				add(RuleAction54, position)
+			}
+			return true
+		},
+		/* 106 Action55 <- <{ p.AddCharacter("-") }> */
+		func() bool {
+			{
+				add(RuleAction55, position)
+			}
+			return true
+		},
+		/* 107 Action56 <- <{ p.AddOctalCharacter(buffer[begin:end]) }> */
+		func() bool {
+			{
+				add(RuleAction56, position)
+			}
+			return true
+		},
+		/* 108 Action57 <- <{ p.AddOctalCharacter(buffer[begin:end]) }> */
+		func() bool {
+			{
+				add(RuleAction57, position)
+			}
+			return true
+		},
+		/* 109 Action58 <- <{ p.AddCharacter("\\") }> */
+		func() bool {
+			{
+				add(RuleAction58, position)
+			}
+			return true
+		},
 	}
 	p.rules = rules
 }
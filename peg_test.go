@@ -0,0 +1,1410 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+/* Parses the grammar into a new tree. */
+func parseGrammar(t *testing.T, grammar string, options Options) *Peg {
+	p := &Peg{Tree: New(options), Buffer: grammar}
+	p.Init()
+	if err := p.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	p.Execute()
+	return p
+}
+
+/* The code of the parser compiled from the grammar. */
+func compileGrammar(t *testing.T, grammar string) string {
+	file := filepath.Join(t.TempDir(), "grammar.peg.go")
+	parseGrammar(t, grammar, Options{}).Compile(file)
+	code, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(code)
+}
+
+/* What the function printed on the standard error. */
+func standardError(t *testing.T, f func()) string {
+	read, write, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr := os.Stderr
+	os.Stderr = write
+	f()
+	os.Stderr = stderr
+	write.Close()
+	output, err := ioutil.ReadAll(read)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(output)
+}
+
+/* What preparing the grammar for compilation printed on the standard error. */
+func prepareErrors(t *testing.T, grammar string) string {
+	p := parseGrammar(t, grammar, Options{})
+	return standardError(t, func() { p.prepare() })
+}
+
+/* Generates the parser of the grammar with the options into a temporary directory along with the Go files,
+   and runs the program, returning what it printed. */
+func runParser(t *testing.T, grammar string, options Options, files map[string]string) string {
+	directory := t.TempDir()
+	parseGrammar(t, grammar, options).Compile(filepath.Join(directory, "grammar.peg.go"))
+	return runProgram(t, directory, files)
+}
+
+/* Parses each input, printing the tokens in the order they were added, leaving out those of the actions,
+   followed by what the actions printed, or fail. */
+const parseDriver = `package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+func main() {
+	for _, input := range %#v {
+		p := &%v{Buffer: input}
+		p.Init()
+		if %v := p.Parse(); err != nil {
+			fmt.Println("fail")
+			continue
+		}
+		var tokens []string
+		for token := range p.Tokens() {
+			if rule := Rul3s[token.Rule]; !strings.HasPrefix(rule, "Action") {
+				tokens = append(tokens, fmt.Sprintf("%%v:%%v-%%v", rule, token.begin, token.end))
+			}
+		}
+		fmt.Print(strings.Join(tokens, " "))
+		%v
+		fmt.Println()
+	}
+}
+`
+
+/* Parses the inputs with the parser generated from the grammar, returning for each what parseDriver printed. */
+func parseInputs(t *testing.T, grammar string, options Options, inputs []string) []string {
+	directory, p := t.TempDir(), parseGrammar(t, grammar, options)
+	p.Compile(filepath.Join(directory, "grammar.peg.go"))
+	parse, execute := "err", ""
+	if p.HasValues {
+		parse = "_, err"
+	}
+	if p.HasActions {
+		execute = "fmt.Print(\" |\")\n\t\tp.Execute()"
+	}
+	output := runProgram(t, directory, map[string]string{
+		"main.go": fmt.Sprintf(parseDriver, inputs, p.StructName, parse, execute)})
+	return strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+}
+
+/* Runs the program made of the files in the directory, returning what it printed. */
+func runProgram(t *testing.T, directory string, files map[string]string) string {
+	files["go.mod"] = "module pegtest\n"
+	for name, code := range files {
+		if err := ioutil.WriteFile(filepath.Join(directory, name), []byte(code), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	command := exec.Command("go", "run", ".")
+	command.Dir = directory
+	output, err := command.CombinedOutput()
+	if err != nil {
+		t.Fatalf("%v\n%s", err, output)
+	}
+	return string(output)
+}
+
+/* The labels example of the README, whose captures are all labeled. */
+func TestLabels(t *testing.T) {
+	grammar := `package main
+
+type Pairs Peg {
+}
+
+pair <- key:<[a-z]+> '=' value:<'x' digits:<[0-9]+> 'y'> { fmt.Println(key, value, digits) }
+`
+	output := runParser(t, grammar, Options{}, map[string]string{"main.go": `package main
+
+func main() {
+	p := &Pairs{Buffer: "ab=x12y"}
+	p.Init()
+	if err := p.Parse(); err != nil {
+		panic(err)
+	}
+	p.Execute()
+}
+`})
+	if output != "ab x12y 12\n" {
+		t.Errorf("printed %q", output)
+	}
+
+	/* a labeled capture within an unlabeled one and the other way round */
+	grammar = `package main
+
+type Nested Peg {
+}
+
+nested <- < 'a' inner:< 'b' > 'c' > { fmt.Println(buffer[begin:end], inner) }
+	outer:< 'd' < 'e' > { fmt.Println(buffer[begin:end]) } 'f' > { fmt.Println(outer) } !.
+`
+	output = runParser(t, grammar, Options{}, map[string]string{"main.go": `package main
+
+func main() {
+	p := &Nested{Buffer: "abcdef"}
+	p.Init()
+	if err := p.Parse(); err != nil {
+		panic(err)
+	}
+	p.Execute()
+}
+`})
+	if output != "abc b\ne\ndef\n" {
+		t.Errorf("printed %q", output)
+	}
+
+	/* begin and end can't hold both of two unlabeled captures */
+	errors := prepareErrors(t, `package main
+
+type Nested Peg {
+}
+
+nested <- < 'a' < 'b' > 'c' > { fmt.Println(buffer[begin:end]) }
+`)
+	if expected := "rule 'nested' nests a capture in another, label one of them\n"; errors != expected {
+		t.Errorf("printed %q instead of %q", errors, expected)
+	}
+}
+
+/* Labels of typed rules, bound to a value when the rule is optional and to a slice when it is repeated. */
+func TestValues(t *testing.T) {
+	grammar := `package main
+
+type Values Peg {
+}
+
+values <- q:number? '|' s:number* '|' p:number+ '|' r:number{2,3} '|' o:number{0,1} !. : string {
+	return fmt.Sprintf("%v %v %v %v %v %T %T", q, s, p, r, o, r, o), nil
+}
+number <- [0-9] : int { return strconv.Atoi(text) }
+`
+	for _, options := range []Options{{}, {Inline: true, Switch: true}} {
+		output := runParser(t, grammar, options, map[string]string{"main.go": `package main
+
+import "fmt"
+
+func main() {
+	for _, input := range []string{"1|23|4|567|8", "||45|67|", "|2|3|4|", "1|2|3|4567|", "1|2|3|4|5"} {
+		p := &Values{Buffer: input}
+		p.Init()
+		value, err := p.Parse()
+		fmt.Println(value, err == nil)
+	}
+}
+`})
+		expected := "1 [2 3] [4] [5 6 7] 8 []int int true\n0 [] [4 5] [6 7] 0 []int int true\n" +
+			"<nil> false\n<nil> false\n<nil> false\n"
+		if output != expected {
+			t.Errorf("with %+v printed %q instead of %q", options, output, expected)
+		}
+	}
+}
+
+/* Reparsing after random edits, and after undoing them, gives the tokens of a full parse of the edited text. */
+func TestReparse(t *testing.T) {
+	grammar := `package main
+
+type Expression Peg {
+}
+
+expression <- sp sum !.
+sum <- product (('+' / '-') sp product)*
+product <- value (('*' / '/') sp value)*
+value <- [0-9]+ sp / '(' sp sum ')' sp
+sp <- ' '*
+`
+	program := `package main
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+func tokens(p *Expression) (tokens []token32) {
+	for token := range p.Tokens() {
+		tokens = append(tokens, token)
+	}
+	return
+}
+
+/* whether the reparse gave the tokens of a full parse */
+func compare(p *Expression, err error) bool {
+	full := &Expression{Buffer: p.Buffer}
+	full.Init()
+	if expected := full.Parse(); (err == nil) != (expected == nil) {
+		fmt.Printf("reparsing %q returned %v instead of %v\n", p.Buffer, err, expected)
+		return false
+	} else if err != nil {
+		return true
+	}
+	reparsed, parsed := tokens(p), tokens(full)
+	if len(reparsed) != len(parsed) {
+		fmt.Printf("reparsing %q gave %v tokens instead of %v\n", p.Buffer, len(reparsed), len(parsed))
+		return false
+	}
+	for i := range parsed {
+		if reparsed[i] != parsed[i] {
+			fmt.Printf("reparsing %q gave the token %v instead of %v\n", p.Buffer, reparsed[i], parsed[i])
+			return false
+		}
+	}
+	return true
+}
+
+func main() {
+	random := rand.New(rand.NewSource(1))
+	p := &Expression{Buffer: "(1 + 23) * 4 - (5 / (6 + 7)) * 89"}
+	p.Init()
+	if err := p.Parse(); err != nil {
+		panic(err)
+	}
+	accepted, rejected := 0, 0
+	for i := 0; i < 2000; i++ {
+		text := []rune(p.Buffer)
+		begin := random.Intn(len(text) + 1)
+		end := begin + random.Intn(3)
+		if end > len(text) {
+			end = len(text)
+		}
+		insert := make([]rune, random.Intn(3))
+		for j := range insert {
+			insert[j] = []rune("0123456789+-*/() ")[random.Intn(17)]
+		}
+
+		err := p.Reparse(Edit{Begin: begin, End: end, Text: string(insert)})
+		if !compare(p, err) {
+			return
+		}
+		if err == nil {
+			accepted++
+		} else {
+			rejected++
+		}
+		if !compare(p, p.Reparse(Edit{Begin: begin, End: begin + len(insert), Text: string(text[begin:end])})) {
+			return
+		}
+		if p.Buffer != string(text) {
+			fmt.Printf("undoing the edit gave %q instead of %q\n", p.Buffer, string(text))
+			return
+		}
+	}
+	fmt.Println(accepted > 0, rejected > 0)
+}
+`
+	for _, options := range []Options{{Incremental: true}, {Incremental: true, Inline: true, Switch: true}} {
+		if output := runParser(t, grammar, options, map[string]string{"main.go": program}); output != "true true\n" {
+			t.Errorf("with %+v printed %q", options, output)
+		}
+	}
+}
+
+/* Streamed records are executed one at a time, whatever the size of the chunks read, even when they split runes. */
+func TestStream(t *testing.T) {
+	grammar := `package main
+
+type Log Peg {
+}
+
+log <- record* !.
+record <- < (!'\n' .)* > '\n' { fmt.Printf("%q ", buffer[begin:end]) }
+`
+	program := `package main
+
+import (
+	"fmt"
+	"io"
+)
+
+/* reads the input a few bytes at a time */
+type chunks struct {
+	input string
+	size  int
+}
+
+func (c *chunks) Read(b []byte) (int, error) {
+	if c.input == "" {
+		return 0, io.EOF
+	}
+	if len(b) > c.size {
+		b = b[:c.size]
+	}
+	n := copy(b, c.input)
+	c.input = c.input[n:]
+	return n, nil
+}
+
+func main() {
+	for _, size := range []int{1, 2, 5, 1 << 20} {
+		for _, input := range []string{"héllo\n日本語\n\nok\n", "ab\nc日"} {
+			p := &Log{}
+			p.Init()
+			fmt.Println(p.ParseReader(&chunks{input, size}))
+		}
+	}
+}
+`
+	output := runParser(t, grammar, Options{Stream: true}, map[string]string{"main.go": program})
+	records := "\"héllo\" \"日本語\" \"\" \"ok\" <nil>\n\"ab\" parse error at rune 4 near \"日\"\n"
+	if expected := strings.Repeat(records, 4); output != expected {
+		t.Errorf("printed %q instead of %q", output, expected)
+	}
+}
+
+/* The rules used by the grammar and the public rules are entry points, also when -inline inlines them, and the
+   rules left out aren't. */
+func TestEntryPoints(t *testing.T) {
+	grammar := `package main
+
+type Entry Peg {
+}
+
+%public word
+
+sum <- sign? number ('+' number)* !.
+sign <- '-'
+number <- digit+
+digit <- [0-9]
+word <- [a-z]+
+unused <- 'x'
+`
+	output := runParser(t, grammar, Options{Inline: true, Switch: true}, map[string]string{"main.go": `package main
+
+import "fmt"
+
+func main() {
+	p := &Entry{}
+	for _, parse := range []struct {
+		rule  Rule
+		input string
+	}{{Rulesum, "1+23"}, {Rulesum, "1+"}, {Rulenumber, "23+"}, {Rulenumber, "+"}, {Ruledigit, "1"}, {Rulesign, "-"}, {Ruleword, "ab"}, {Ruleunused, "x"}, {RuleUnknown, ""}} {
+		if err := p.ParseString(parse.rule, parse.input); err != nil {
+			if _, ok := err.(*parseError); ok {
+				err = fmt.Errorf("parse error")
+			}
+			fmt.Println(err)
+			continue
+		}
+		for token := range p.Tokens() {
+			fmt.Print(Rul3s[token.Rule], " ", token.begin, "-", token.end, " ")
+		}
+		fmt.Println()
+	}
+}
+`})
+	expected := "digit 0-1 number 0-1 digit 2-3 digit 3-4 number 2-4 sum 0-4 \nparse error\n" +
+		"digit 0-1 digit 1-2 number 0-2 \nparse error\ndigit 0-1 \n" +
+		"sign 0-1 \nword 0-2 \nrule unused isn't an entry point\nrule Unknown isn't an entry point\n"
+	if output != expected {
+		t.Errorf("printed %q instead of %q", output, expected)
+	}
+}
+
+/* Match matches rules at rune offsets without requiring the rest of the input to match. */
+func TestMatch(t *testing.T) {
+	grammar := `package main
+
+type Scan Peg {
+}
+
+tokens <- (number / word / ' ')* !.
+number <- [0-9]+
+word <- [a-zé]+
+`
+	output := runParser(t, grammar, Options{}, map[string]string{"main.go": `package main
+
+import "fmt"
+
+func main() {
+	p, input := &Scan{}, "ab 12 é3"
+	for offset := 0; offset < len([]rune(input)); offset++ {
+		for _, rule := range []Rule{Rulenumber, Ruleword} {
+			if end, ok := p.Match(rule, input, offset); ok {
+				fmt.Print(Rul3s[rule], " ", offset, "-", end, " ")
+				offset = end - 1
+			}
+		}
+	}
+	fmt.Println()
+	fmt.Println(p.Match(Rulenumber, input, 2))
+	fmt.Println(p.Match(Rulenumber, input, 8))
+	fmt.Println(p.Match(Rulenumber, input, -1))
+	fmt.Println(p.Match(RuleUnknown, input, 0))
+	fmt.Println(p.Match(Rulenumber, "42", 1))
+}
+`})
+	expected := "word 0-2 number 3-5 word 6-7 number 7-8 \n0 false\n0 false\n0 false\n0 false\n2 true\n"
+	if output != expected {
+		t.Errorf("printed %q instead of %q", output, expected)
+	}
+}
+
+/* The %skip example of the README, with spaces between every token or none. */
+func TestSkip(t *testing.T) {
+	grammar := `package main
+
+type Sum Peg {
+}
+
+%skip spacing
+%lexer number
+
+sum <- number ('+' number)* !.
+number <- [0-9]+
+spacing <- (' ' / '\t')*
+`
+	for _, options := range []Options{{}, {Inline: true, Switch: true}} {
+		output := runParser(t, grammar, options, map[string]string{"main.go": `package main
+
+import "fmt"
+
+func main() {
+	for _, input := range []string{"1+2", "1 + 2", "1+ 2", "1 +2+ 3", " 1 + 2 + 3 ", "\t12\t+ 3", "1 2", "1 + + 2", "1 2 + 3"} {
+		p := &Sum{Buffer: input}
+		p.Init()
+		fmt.Println(p.Parse() == nil)
+	}
+}
+`})
+		if expected := "true\ntrue\ntrue\ntrue\ntrue\ntrue\nfalse\nfalse\nfalse\n"; output != expected {
+			t.Errorf("with %+v printed %q instead of %q", options, output, expected)
+		}
+	}
+
+	/* a literal and a predicate on terminals are single tokens, skipped before but not within */
+	grammar = `package main
+
+type Statement Peg {
+}
+
+%skip sp
+%lexer id
+
+stmt <- 'if' id 'then' id !.
+id <- [a-z]+
+sp <- ' '*
+`
+	inputs := map[string]bool{
+		"if x then y":     true,
+		"  if x  then y ": true,
+		"if x theny":      true,
+		"i f x then y":    false,
+		"if x th en y":    false,
+		"if x then y z":   false,
+	}
+	for _, options := range []Options{{}, {Inline: true, Switch: true}} {
+		var names []string
+		for input := range inputs {
+			names = append(names, input)
+		}
+		sort.Strings(names)
+		expected := ""
+		for _, input := range names {
+			expected += fmt.Sprintln(inputs[input])
+		}
+		output := runParser(t, grammar, options, map[string]string{"main.go": fmt.Sprintf(`package main
+
+import "fmt"
+
+func main() {
+	for _, input := range %#v {
+		p := &Statement{Buffer: input}
+		p.Init()
+		fmt.Println(p.Parse() == nil)
+	}
+}
+`, names)})
+		if output != expected {
+			t.Errorf("with %+v printed %q instead of %q", options, output, expected)
+		}
+	}
+	/* the interpreter of -run agrees */
+	tree := parseGrammar(t, grammar, Options{}).Tree
+	tree.interpretable()
+	for input, accepted := range inputs {
+		if _, err := tree.interpret("", input, nil); (err == nil) != accepted {
+			t.Errorf("-run of %q gave %v", input, err)
+		}
+	}
+}
+
+/* Formatting peg.peg and the example grammars doesn't change the parsers they compile into, and formatting
+   them again doesn't change them. */
+func TestFormat(t *testing.T) {
+	grammars, err := filepath.Glob(filepath.Join("grammars", "*", "*.peg"))
+	if err != nil || len(grammars) == 0 {
+		t.Fatalf("no example grammars: %v", err)
+	}
+	for _, file := range append(grammars, "peg.peg") {
+		source, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		formatted := parseGrammar(t, string(source), Options{}).Format()
+		if again := parseGrammar(t, formatted, Options{}).Format(); again != formatted {
+			t.Errorf("formatting the formatted %v changed it", file)
+		}
+		if compileGrammar(t, string(source)) != compileGrammar(t, formatted) {
+			t.Errorf("the formatted %v compiles into another parser", file)
+		}
+	}
+
+	/* blank lines, comments and the columns of actions and trailing comments are kept */
+	grammar := `package main
+
+# the parser
+type Format Peg {
+}
+
+# values
+Value	<- Number	{ p.Value() }   # a number
+	 / '(' Sum ')'
+Number <- [0-9]+ { p.Number() }
+
+Sum <- Value ('+' Value)*
+`
+	expected := `package main
+
+# the parser
+type Format Peg {
+}
+
+# values
+Value  <- Number        { p.Value() }   # a number
+       / '(' Sum ')'
+Number <- [0-9]+ { p.Number() }
+
+Sum <- Value ('+' Value)*
+`
+	if formatted := parseGrammar(t, grammar, Options{}).Format(); formatted != expected {
+		t.Errorf("formatted the grammar into\n%v\ninstead of\n%v", formatted, expected)
+	}
+}
+
+/* The grammar exported in each notation, leaving out actions and annotating predicates and back references,
+   and exported as peg compiling into the same parser. */
+func TestExport(t *testing.T) {
+	grammar := `package main
+
+type Export Peg {
+}
+
+sum <- value ('+' value)* !. { p.Sum() }
+value <- tag:<[0-9]+> $tag? / '(' sum ')' &{ p.Ok() }
+    %left '*' / '/'
+`
+	exports := map[string]string{
+		"ebnf": `sum   = value , { "+" , value } (* not followed by ? any character ? *) ;
+value = ( ? [0-9] ? , { ? [0-9] ? } , [ ? the text matched by tag ? ] | "(" , sum , ")" ) , ` +
+			`{ ( "*" | "/" ) , ( ? [0-9] ? , { ? [0-9] ? } , [ ? the text matched by tag ? ] | "(" , sum , ")" ) } ;
+`,
+		"w3c-ebnf": `sum   ::= value ( "+" value )* /* not followed by [#x0-#x10FFFF] */
+value ::= ( [0-9]+ /* the text matched by tag */? | "(" sum ")" ) ` +
+			`( ( "*" | "/" ) ( [0-9]+ /* the text matched by tag */? | "(" sum ")" ) )*
+`,
+		"peg": `package main
+
+type Export Peg {
+}
+
+sum   <- value ('+' value)* !. { p.Sum() }
+value <- tag:<[0-9]+> $tag? / '(' sum ')' &{ p.Ok() } %left '*' / '/'
+`,
+	}
+	for notation, expected := range exports {
+		var out strings.Builder
+		if err := parseGrammar(t, grammar, Options{}).Export(&out, notation); err != nil {
+			t.Fatal(err)
+		}
+		if out.String() != expected {
+			t.Errorf("exported as %v into\n%v\ninstead of\n%v", notation, out.String(), expected)
+		}
+	}
+
+	var railroad strings.Builder
+	if err := parseGrammar(t, grammar, Options{}).Export(&railroad, "railroad"); err != nil {
+		t.Fatal(err)
+	}
+	if page := railroad.String(); strings.Count(page, "<svg") != 2 || !strings.Contains(page, "value") {
+		t.Errorf("exported as railroad without a diagram of each rule:\n%v", page)
+	}
+	if err := parseGrammar(t, grammar, Options{}).Export(ioutil.Discard, "xml"); err == nil {
+		t.Error("exported in an unknown notation")
+	}
+
+	if compileGrammar(t, exports["peg"]) != compileGrammar(t, grammar) {
+		t.Error("the grammar exported as peg compiles into another parser")
+	}
+}
+
+/* Grammars of leg, pigeon and pegen imported and exported as peg, reporting what they drop, and a left
+   recursive pegen grammar failing to import. */
+func TestImport(t *testing.T) {
+	imports := []struct {
+		format, file, source, grammar, reported string
+	}{
+		{"leg", "calc.leg", `%{
+#include <stdio.h>
+%}
+
+expr-list = term ( "+" term )* { $$ = 1; }
+term = < [0-9]+ > -
+- = [ \t]*
+
+%%
+int main() {}
+`, `expr_list <- term ('+' term)*
+term      <- <[0-9]+> _
+_         <- (' ' / '\t')*
+`, `calc.leg:1: %{ %} declarations can't be translated, dropped
+calc.leg:9: %% trailer can't be translated, dropped
+calc.leg: 1 actions can't be translated, dropped
+`},
+		{"pigeon", "calc.peg", `{
+package main
+}
+
+Expr "expression" <- Term ( "+" Term )* {
+    return nil, nil
+}
+Term <- [0-9]+
+`, `Expr <- Term ('+' Term)*
+Term <- [0-9]+
+`, `calc.peg:1: initializer can't be translated, dropped
+calc.peg:5: rule Expr display name "expression" dropped
+calc.peg: 1 actions can't be translated, dropped
+`},
+		{"pegen", "calc.gram", `start: expr NEWLINE
+expr: term ('+' term)*
+term: NUMBER
+`, `start <- expr NEWLINE
+expr  <- term ('+' term)*
+term  <- NUMBER
+`, `calc.gram: undefined rules NEWLINE, NUMBER match the empty string
+`},
+	}
+	for _, i := range imports {
+		tree, out := New(Options{}), &strings.Builder{}
+		reported := standardError(t, func() {
+			if err := tree.Import(i.format, i.file, i.source); err != nil {
+				t.Error(err)
+			}
+		})
+		if err := tree.Export(out, "peg"); err != nil {
+			t.Fatal(err)
+		}
+		if expected := "package main\n\ntype Calc Peg {}\n\n" + i.grammar; out.String() != expected {
+			t.Errorf("imported %v into\n%v\ninstead of\n%v", i.format, out, expected)
+		}
+		if reported != i.reported {
+			t.Errorf("importing %v reported\n%v\ninstead of\n%v", i.format, reported, i.reported)
+		}
+	}
+
+	err := New(Options{}).Import("pegen", "left.gram", "expr: expr '+' term | term\nterm: NUMBER\n")
+	if expected := "left.gram:1: left recursive rules expr can't be translated"; fmt.Sprint(err) != expected {
+		t.Errorf("importing a left recursive grammar returned %v instead of %v", err, expected)
+	}
+	if err := New(Options{}).Import("yacc", "calc.y", ""); err == nil {
+		t.Error("imported an unknown format")
+	}
+}
+
+/* A session of the playground, parsing lines with the start rule and another, tracing, and reloading the
+   grammar after it has changed. */
+func TestRepl(t *testing.T) {
+	grammars := []string{`package main
+
+type Repl Peg {
+}
+
+sum <- number ('+' number)*
+number <- [0-9]+
+`, `package main
+
+type Repl Peg {
+}
+
+sum <- number ('-' number)*
+number <- [0-9]+
+`}
+	loads := 0
+	load := func() (*Tree, error) {
+		grammar := grammars[loads%len(grammars)]
+		loads++
+		return parseGrammar(t, grammar, Options{}).Tree, nil
+	}
+	var out strings.Builder
+	script := "1+2\n1+*2\n12a\n:rule\n:rule number\n4x\n:rule product\n:trace\n7\n:trace\n:reload\n:rule sum\n1-2\n::x\n:what\n:quit\n1\n"
+	if err := Repl(strings.NewReader(script), &out, load); err != nil {
+		t.Fatal(err)
+	}
+	expected := `starting with rule 'sum', :help lists the commands
+>  number "1"
+ _In_ "+"
+ number "2"
+>  number "1"
+   ^
+the rule matched the line up to symbol 2
+>  number "12"
+    ^
+the rule matched the line up to symbol 3
+> sum (start)
+number
+> >    ^
+the rule matched the line up to symbol 2
+> undefined rule 'product'
+> tracing the calls of rules
+> number at 0
+number matched "7"
+> not tracing
+> > >  number "1"
+ _In_ "-"
+ number "2"
+>   ^
+parse error at line 1 symbol 1, expected [0-9]
+> unknown command :what, :help lists the commands
+> `
+	if printed := strings.NewReplacer("\x1B[34m", "", "\x1B[m", "").Replace(out.String()); printed != expected {
+		t.Errorf("printed\n%v\ninstead of\n%v", printed, expected)
+	}
+	if loads != 2 {
+		t.Errorf("loaded the grammar %v times instead of 2", loads)
+	}
+}
+
+/* A parser generated with -trace prints the calls of its rules when its Trace writer is set, as -run prints
+   them, and a parser generated without it has no tracing code. */
+func TestTrace(t *testing.T) {
+	grammar := `package main
+
+type Sum Peg {
+}
+
+sum <- number ('+' number)* !.
+number <- digit+
+digit <- [0-9]
+`
+	output := runParser(t, grammar, Options{Tracing: true}, map[string]string{"main.go": `package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	for _, input := range []string{"1+2", "1+"} {
+		p := &Sum{Buffer: input, Trace: os.Stdout}
+		p.Init()
+		fmt.Println(p.Parse() == nil)
+	}
+	p := &Sum{Buffer: "1"}
+	p.Init()
+	fmt.Println(p.Parse() == nil)
+}
+`})
+	traces := []string{`sum at 0
+ number at 0
+  digit at 0
+  digit matched "1"
+  digit at 1
+  digit failed
+ number matched "1"
+ number at 2
+  digit at 2
+  digit matched "2"
+  digit at 3
+  digit failed
+ number matched "2"
+sum matched "1+2"
+`, `sum at 0
+ number at 0
+  digit at 0
+  digit matched "1"
+  digit at 1
+  digit failed
+ number matched "1"
+ number at 2
+  digit at 2
+  digit failed
+ number failed
+sum failed
+`}
+	if expected := traces[0] + "true\n" + traces[1] + "false\ntrue\n"; output != expected {
+		t.Errorf("printed\n%v\ninstead of\n%v", output, expected)
+	}
+
+	/* the rules compiled in place aren't calls */
+	output = runParser(t, grammar, Options{Tracing: true, Inline: true}, map[string]string{"main.go": `package main
+
+import "os"
+
+func main() {
+	p := &Sum{Buffer: "12", Trace: os.Stdout}
+	p.Init()
+	p.Parse()
+}
+`})
+	if expected := "sum at 0\n number at 0\n number matched \"12\"\nsum matched \"12\"\n"; output != expected {
+		t.Errorf("with -inline printed\n%v\ninstead of\n%v", output, expected)
+	}
+
+	var run strings.Builder
+	if err := parseGrammar(t, grammar, Options{Tracing: true}).Run(&run, "", "1+2"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(run.String(), traces[0]) {
+		t.Errorf("-run printed\n%v\ninstead of\n%v", run.String(), traces[0])
+	}
+	if strings.Contains(compileGrammar(t, grammar), "Trace") {
+		t.Error("a parser generated without -trace has tracing code")
+	}
+}
+
+/* A parser generated with -profile counts the calls, matches, failures and backtracks of each rule over its
+   parses, and the calls answered by the previous parse when reparsing. */
+func TestProfile(t *testing.T) {
+	grammar := `package main
+
+type Words Peg {
+}
+
+start <- (keyword / word / ' ')* !.
+keyword <- ('if' / 'else') ![a-z]
+word <- [a-z]+
+`
+	output := runParser(t, grammar, Options{Profiling: true, Incremental: true}, map[string]string{"main.go": `package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+func main() {
+	p := &Words{Buffer: "if iffy else"}
+	p.Init()
+	print := func() {
+		for _, r := range p.Profile {
+			if r.Calls > 0 {
+				fmt.Println(Rul3s[r.Rule], r.Calls, r.Matches, r.Failures, r.Backtracks, r.Reused, r.Time >= r.Self)
+			}
+		}
+	}
+	fmt.Println(p.Parse() == nil)
+	print()
+	fmt.Println(p.Reparse(Edit{Begin: 3, End: 3, Text: "x"}) == nil)
+	print()
+	var out strings.Builder
+	p.PrintProfile(&out)
+	fmt.Println(strings.Fields(out.String())[:8], strings.Count(out.String(), "\n"))
+}
+`})
+	/* keyword backtracks over the if of iffy, and reparsing reuses its two matches */
+	expected := `true
+start 1 1 0 0 0 true
+keyword 6 2 4 1 0 true
+word 4 1 3 0 0 true
+true
+start 2 2 0 0 0 true
+keyword 12 4 8 1 2 true
+word 8 2 6 0 0 true
+[rule calls matches failures backtracks reused time self] 4
+`
+	if output != expected {
+		t.Errorf("printed\n%v\ninstead of\n%v", output, expected)
+	}
+}
+
+/* The benchmark of a parser over a corpus directory counts its parses, tokens and failures. */
+func TestBench(t *testing.T) {
+	if testing.Short() {
+		t.Skip("times the parser for a second")
+	}
+	directory := t.TempDir()
+	files := map[string]string{
+		"sum.peg": `package sum
+
+type Sum Peg {
+}
+
+sum <- number ('+' number)* !.
+number <- [0-9]+
+`,
+		filepath.Join("corpus", "a.txt"):           "1+2",
+		filepath.Join("corpus", "nested", "b.txt"): "12+3+45",
+		filepath.Join("corpus", "c.txt"):           "1+",
+	}
+	for name, content := range files {
+		path := filepath.Join(directory, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	corpus, err := benchCorpus(filepath.Join(directory, "corpus"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corpus) != 3 {
+		t.Errorf("the corpus has the files %v", corpus)
+	}
+	if _, err := benchCorpus(t.TempDir()); err == nil {
+		t.Error("an empty corpus was accepted")
+	}
+
+	grammar := filepath.Join(directory, "sum.peg")
+	tree := parseGrammar(t, files["sum.peg"], Options{Inline: true, Switch: true}).Tree
+	r, err := benchParser(filepath.Join(directory, "bench"), grammar, corpus, tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	/* each parse of the corpus parses its 12 bytes, the 7 tokens of its first two files and fails on 1+ */
+	if r.parses == 0 || r.parses%3 != 0 || r.elapsed < int64(time.Second) || r.bytes != r.parses/3*12 {
+		t.Errorf("timed %v parses of %v bytes in %v", r.parses, r.bytes, time.Duration(r.elapsed))
+	}
+	if r.tokens != 7 || r.failures != 1 {
+		t.Errorf("counted %v tokens and %v failures instead of 7 and 1", r.tokens, r.failures)
+	}
+}
+
+/* The counts written by a parser generated with -cover, by two runs appending them, reported onto the grammar
+   as text and as a page, and refused for a grammar that has changed. */
+func TestCover(t *testing.T) {
+	grammar := `package main
+
+type Calc Peg {
+}
+
+sum <- number ('+' number / '-' number)* !.
+number <- [0-9]+
+        / 'x'
+`
+	counts := runParser(t, grammar, Options{Covering: true}, map[string]string{"main.go": `package main
+
+import "os"
+
+func main() {
+	for _, input := range []string{"1+2", "3+4+5"} {
+		p := &Calc{Buffer: input}
+		p.Init()
+		p.Parse()
+	}
+	WriteCalcCoverage(os.Stdout)
+}
+`})
+	directory := t.TempDir()
+	file, profile := filepath.Join(directory, "calc.peg"), filepath.Join(directory, "calc.cover")
+	if err := ioutil.WriteFile(file, []byte(grammar), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(profile, []byte(counts+counts), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var report, page strings.Builder
+	if err := CoverReport(&report, file, profile, false); err != nil {
+		t.Fatal(err)
+	}
+	expected := `calc.peg:6.1:  sum    4  1/2 alternatives
+calc.peg:6.29:        0  '-' number
+calc.peg:7.1:  number 10 1/2 alternatives
+calc.peg:8.11:        0  'x'
+total: rules 2/2, alternatives 2/4, 66.7% matched
+`
+	if printed := strings.ReplaceAll(report.String(), directory+string(filepath.Separator), ""); printed != expected {
+		t.Errorf("reported\n%v\ninstead of\n%v", printed, expected)
+	}
+	if err := CoverReport(&page, file, profile, true); err != nil {
+		t.Fatal(err)
+	}
+	for _, span := range []string{`<span class="cov1" title="matched 4 times">sum</span>`,
+		`<span class="cov1" title="matched 10 times">[0-9]+</span>`,
+		`<span class="cov0" title="matched 0 times">&#39;x&#39;</span>`} {
+		if !strings.Contains(page.String(), span) {
+			t.Errorf("the page has no %v:\n%v", span, page.String())
+		}
+	}
+
+	if err := ioutil.WriteFile(file, []byte(strings.Replace(grammar, "sum", "total", 1)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := CoverReport(ioutil.Discard, file, profile, false); err == nil {
+		t.Error("reported the counts onto a grammar that has changed")
+	}
+}
+
+/* The inputs derived from a grammar with predicates and back references match it, the same seed derives
+   the same inputs, and the seed corpus holds them in the format of go test. */
+func TestGenerateInputs(t *testing.T) {
+	grammar := `package main
+
+type Gen Peg {
+}
+
+document <- (item (',' item)*)? !.
+item     <- heredoc / number / list
+heredoc  <- '<<' tag:<[A-Z]{1,3}> ':' (!('.' $tag) [a-z.])* '.' $tag
+number   <- '-'? [0-9]{1,3} !'x'
+list     <- '[' (item (' ' item)*)? ']'
+`
+	generate := func(seed int64, depth int, corpus string) string {
+		var out strings.Builder
+		reported := standardError(t, func() {
+			if err := parseGrammar(t, grammar, Options{}).GenerateInputs(&out, 300, seed, depth, corpus); err != nil {
+				t.Error(err)
+			}
+		})
+		if reported != "" {
+			t.Errorf("with the seed %v and the depth %v reported %v", seed, depth, reported)
+		}
+		return out.String()
+	}
+	tree := parseGrammar(t, grammar, Options{}).Tree
+	tree.interpretable()
+	inputs := generate(1, 48, "")
+	for _, line := range strings.Split(strings.TrimSpace(inputs), "\n") {
+		input, err := strconv.Unquote(line)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tree.interpret("", input, nil); err != nil {
+			t.Errorf("derived %q, which doesn't match: %v", input, err)
+		}
+	}
+	if strings.Count(inputs, "<<") == 0 || strings.Count(inputs, "[") == 0 {
+		t.Errorf("derived no heredocs or lists:\n%v", inputs)
+	}
+	if generate(1, 48, "") != inputs {
+		t.Error("the same seed derived other inputs")
+	}
+	if generate(2, 48, "") == inputs {
+		t.Error("another seed derived the same inputs")
+	}
+	generate(1, 2, "")
+
+	corpus := filepath.Join(t.TempDir(), "FuzzParse")
+	generate(1, 48, corpus)
+	entries, err := ioutil.ReadDir(corpus)
+	if err != nil || len(entries) == 0 {
+		t.Fatalf("wrote no corpus: %v", err)
+	}
+	for _, entry := range entries {
+		content, err := ioutil.ReadFile(filepath.Join(corpus, entry.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.Split(string(content), "\n")
+		if len(lines) != 3 || lines[0] != "go test fuzz v1" || !strings.HasPrefix(lines[1], "string(") ||
+			!strings.Contains(inputs, strings.TrimSuffix(strings.TrimPrefix(lines[1], "string("), ")")) {
+			t.Errorf("wrote the corpus entry %q", content)
+		}
+	}
+
+	never := "package main\n\ntype Never Peg {\n}\n\nlist <- 'x' list\n"
+	if err := parseGrammar(t, never, Options{}).GenerateInputs(ioutil.Discard, 1, 1, 48, ""); fmt.Sprint(err) !=
+		"rule 'list' never ends" {
+		t.Errorf("derived inputs from a rule that never ends: %v", err)
+	}
+}
+
+/* The fuzz target written with -fuzz passes over its seeds, and neither it nor a parser generated with the
+   options adding code has a doc comment that go/printer misaligned. */
+func TestFuzz(t *testing.T) {
+	grammar := `package main
+
+type Fuzz Peg {
+	numbers int
+}
+
+sum <- number ('+' number)* !.
+number <- <[0-9]+> { p.numbers++ }
+`
+	directory := t.TempDir()
+	tree := parseGrammar(t, grammar, Options{Incremental: true, Tracing: true, Profiling: true, Covering: true}).Tree
+	tree.Compile(filepath.Join(directory, "fuzz.peg.go"))
+	if err := tree.WriteFuzzTest(filepath.Join(directory, "fuzz_fuzz_test.go")); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{"go.mod": "module pegfuzz\n", "main.go": "package main\n\nfunc main() {}\n",
+		filepath.Join("testdata", "fuzz", "FuzzParse", "seed"): "go test fuzz v1\nstring(\"1+23\")\n"}
+	for name, content := range files {
+		path := filepath.Join(directory, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"fuzz.peg.go", "fuzz_fuzz_test.go"} {
+		code, err := ioutil.ReadFile(filepath.Join(directory, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(code), "\n/*\n") {
+			t.Errorf("%v has a doc comment rewritten by go/printer", name)
+		}
+	}
+	command := exec.Command("go", "test", "-count=1", "-v", "-run", "^FuzzParse$")
+	command.Dir = directory
+	output, err := command.CombinedOutput()
+	if err != nil || !strings.Contains(string(output), "FuzzParse/seed") {
+		t.Fatalf("%v\n%s", err, output)
+	}
+}
+
+/* A parser is stale when its grammar or the version of peg changed, or, for -update, when it was generated
+   with other options, and -check reports each stale parser. */
+func TestStale(t *testing.T) {
+	for version, expected := range map[string]string{"v1.2.0": "v1.2.0", "v2.0.0+incompatible": "v2.0.0+incompatible",
+		"": "dev", "(devel)": "dev", "v1.2.0+dirty": "dev", "v0.0.0-20240102150405-abcdef123456": "dev",
+		"v1.2.1-0.20240102150405-abcdef123456": "dev"} {
+		if released := releaseVersion(version); released != expected {
+			t.Errorf("the version of a build at %q is %v instead of %v", version, released, expected)
+		}
+	}
+
+	directory := t.TempDir()
+	grammar := `package main
+
+type Sum Peg {
+}
+
+sum <- [0-9]+ ('+' [0-9]+)* !.
+`
+	file := filepath.Join(directory, "sum.peg")
+	write := func(name, content string) {
+		if err := ioutil.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	generate := func(command string) {
+		write(file, grammar)
+		p := parseGrammar(t, grammar, Options{Inline: true})
+		p.Command, p.Version, p.Hash = command, Version, grammarHash(grammar)
+		p.Compile(file + ".go")
+	}
+	check := func(command, expected string) {
+		if err := stale(file, command); fmt.Sprint(err) != expected {
+			t.Errorf("the parser is stale because of %v instead of %v", err, expected)
+		}
+	}
+
+	generate("peg -inline sum.peg")
+	check("", "<nil>")
+	check("peg -inline sum.peg", "<nil>")
+	check("peg -switch sum.peg", file+".go was generated by peg -inline sum.peg, not peg -switch sum.peg")
+	write(file, grammar+"\n")
+	check("", file+".go is out of date with "+file+": run peg -inline sum.peg")
+
+	generate("peg -inline sum.peg")
+	version := Version
+	Version = "v1.2.0"
+	check("", file+".go was generated by peg "+version+", not v1.2.0: run peg -inline sum.peg")
+	Version = version
+
+	write(file+".go", "package main\n")
+	check("", file+".go doesn't record the grammar it was generated from")
+	os.Remove(file + ".go")
+	if err := stale(file, ""); !os.IsNotExist(err) {
+		t.Errorf("the missing parser is stale because of %v", err)
+	}
+
+	generated := filepath.Join(directory, "generated.peg")
+	write(generated, grammar)
+	p := parseGrammar(t, grammar, Options{})
+	p.Command, p.Version, p.Hash = "peg generated.peg", Version, grammarHash(grammar)
+	p.Compile(generated + ".go")
+	var err error
+	reported := standardError(t, func() { err = Check([]string{generated, file}) })
+	if expected := "1 of the 2 parsers need to be generated again"; fmt.Sprint(err) != expected {
+		t.Errorf("checking returned %v instead of %v", err, expected)
+	}
+	if !strings.Contains(reported, file+".go") || strings.Contains(reported, generated+".go") {
+		t.Errorf("checking reported %v", reported)
+	}
+	if err := Check([]string{generated}); err != nil {
+		t.Errorf("checking an up to date parser returned %v", err)
+	}
+}
+
+/* Grammars with the parses of their inputs, as printed by parseDriver. */
+var grammarTests = []struct {
+	name, grammar string
+	options       Options
+	parses        map[string]string
+}{
+	{"cut", `package main
+
+type Cut Peg {
+}
+
+start <- statement !.
+statement <- 'if' ^ condition / 'i' [a-z]+ / list
+condition <- [0-9]+
+list <- ('a' ^ 'b')* 'ac'
+`, Options{}, map[string]string{
+		"if12": "condition:2-4 statement:0-4 start:0-4",
+		"ix":   "statement:0-2 start:0-2",
+		/* after the cut the other alternatives aren't tried */
+		"ifx": "fail",
+		/* nor is the loop left */
+		"abac": "fail",
+	}},
+	/* with -switch an alternative without a first character is the default case wherever it is */
+	{"switch default", `package main
+
+type Switch Peg {
+}
+
+start <- ('x' (!. / 'a' / [b-d]))+
+`, Options{Switch: true}, map[string]string{
+		"x":    "start:0-1",
+		"xaxb": "start:0-4",
+		"xax":  "start:0-3",
+		"xe":   "fail",
+		"xx":   "fail",
+	}},
+	/* a lexer rule adds one token and none for the rules it uses */
+	{"lexer", `package main
+
+type Lexer Peg {
+}
+
+%lexer identifier
+
+start <- identifier (',' identifier)* !.
+identifier <- letter (letter / digit)* '!' / letter+
+letter <- [a-z]
+digit <- [0-9]
+`, Options{}, map[string]string{
+		"ab,c":  "identifier:0-2 identifier:3-4 start:0-4",
+		"a1!,b": "identifier:0-3 identifier:4-5 start:0-5",
+		"ab1,c": "fail",
+		"ab,,c": "fail",
+		"1a":    "fail",
+	}},
+	/* a suppressed rule adds no token, the tokens of the rules it uses are kept */
+	{"suppress", `package main
+
+type Suppress Peg {
+}
+
+start <- word (spacing word)* !.
+word <- [a-z]+
+~spacing <- (' ' / comment)+
+comment <- '#' [0-9]*
+`, Options{}, map[string]string{
+		"a b":    "word:0-1 word:2-3 start:0-3",
+		"a #1 b": "word:0-1 comment:2-4 word:5-6 start:0-6",
+		"a#b":    "word:0-1 comment:1-2 word:2-3 start:0-3",
+		"a  ":    "fail",
+	}},
+	/* operators bind by level and associativity, and the action of an operator runs after its operands */
+	{"precedence", `package main
+
+type Calc Peg {
+}
+
+start <- sum !.
+sum <- value
+       %left '+' { fmt.Print(" +") } / '-' { fmt.Print(" -") }
+       %left '*' { fmt.Print(" *") }
+       %right '^' { fmt.Print(" ^") }
+value <- <[0-9]> { fmt.Print(" ", buffer[begin:end]) } / '(' sum ')'
+`, Options{}, map[string]string{
+		"1":     "PegText:0-1 value:0-1 sum:0-1 start:0-1 | 1",
+		"1-2-3": "PegText:0-1 value:0-1 PegText:2-3 value:2-3 sum:0-3 PegText:4-5 value:4-5 sum:0-5 sum:0-5 start:0-5 | 1 2 - 3 -",
+		"2^3^4": "PegText:0-1 value:0-1 PegText:2-3 value:2-3 PegText:4-5 value:4-5 sum:2-5 sum:0-5 sum:0-5 start:0-5 | 2 3 4 ^ ^",
+		"1+2*3": "PegText:0-1 value:0-1 PegText:2-3 value:2-3 PegText:4-5 value:4-5 sum:2-5 sum:0-5 sum:0-5 start:0-5 | 1 2 3 * +",
+		"1*2+3": "PegText:0-1 value:0-1 PegText:2-3 value:2-3 sum:0-3 PegText:4-5 value:4-5 sum:0-5 sum:0-5 start:0-5 | 1 2 * 3 +",
+		"(1+2)*3": "PegText:1-2 value:1-2 PegText:3-4 value:3-4 sum:1-4 sum:1-4 value:0-5 PegText:6-7 value:6-7 sum:0-7 " +
+			"sum:0-7 start:0-7 | 1 2 + 3 *",
+		"1-2*3^4^5+6": "PegText:0-1 value:0-1 PegText:2-3 value:2-3 PegText:4-5 value:4-5 PegText:6-7 value:6-7 " +
+			"PegText:8-9 value:8-9 sum:6-9 sum:4-9 sum:2-9 sum:0-9 PegText:10-11 value:10-11 sum:0-11 sum:0-11 " +
+			"start:0-11 | 1 2 3 4 5 ^ ^ * - 6 +",
+		"1+":   "fail",
+		"1**2": "fail",
+		"(1":   "fail",
+	}},
+	/* a back reference matches the text of its label, as it was before the alternatives that failed */
+	{"back reference", `package main
+
+type Back Peg {
+}
+
+start <- (heredoc / pair) !.
+heredoc <- '<<' tag:<[A-Z]+> '\n' (!('\n' $tag '\n') .)* '\n' $tag '\n'
+pair <- tag:<[a-z]> (tag:<[a-z]+> '!' / [a-z]* '=') $tag
+`, Options{}, map[string]string{
+		"<<EOF\nx\nEOF\n": "PegText:2-5 Label_tag:2-5 heredoc:0-12 start:0-12",
+		"<<A\nA\nA\n":     "PegText:2-3 Label_tag:2-3 heredoc:0-8 start:0-8",
+		"<<EOF\nx\nEOX\n": "fail",
+		"ab!b":            "PegText:0-1 Label_tag:0-1 PegText:1-2 Label_tag:1-2 pair:0-4 start:0-4",
+		"ab!a":            "fail",
+		/* the label bound to b by the first alternative is restored to a */
+		"ab=a": "PegText:0-1 Label_tag:0-1 pair:0-4 start:0-4",
+		"ab=b": "fail",
+	}},
+	/* a bounded repetition matches exactly n, at least n, or from n up to m times */
+	{"repeat", `package main
+
+type Repeat Peg {
+}
+
+start <- hex{2} 'x'{1,} 'y'{2,4} !.
+hex <- [0-9a-f]
+`, Options{}, map[string]string{
+		"a0xyy":     "hex:0-1 hex:1-2 start:0-5",
+		"a0xxxyyyy": "hex:0-1 hex:1-2 start:0-9",
+		"axyy":      "fail",
+		"a0fxyy":    "fail",
+		"a0yy":      "fail",
+		"a0xy":      "fail",
+		"a0xyyyyy":  "fail",
+	}},
+}
+
+func TestGrammars(t *testing.T) {
+	for _, test := range grammarTests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			inputs := make([]string, 0, len(test.parses))
+			for input := range test.parses {
+				inputs = append(inputs, input)
+			}
+			sort.Strings(inputs)
+			for i, parse := range parseInputs(t, test.grammar, test.options, inputs) {
+				if expected := test.parses[inputs[i]]; parse != expected {
+					t.Errorf("parsed %q into %q instead of %q", inputs[i], parse, expected)
+				}
+			}
+		})
+	}
+}
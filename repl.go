@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const replHelp = `Each line is parsed with the start rule and its syntax tree is printed.
+:rule [name]  starts with the named rule, or lists the rules
+:trace        prints the calls of rules while parsing, or stops printing them
+:reload       reads the grammar again
+:quit         leaves
+A line starting with :: is parsed without its first colon.
+`
+
+/* The rules written in the grammar, in the order they are defined. */
+func (t *Tree) definedRules() (rules []string) {
+	t.prepare()
+	for _, rule := range t.RuleNames {
+		if expression := rule.Front(); expression.GetType() == TypeImplicitPush {
+			if element := expression.Front().GetType(); element != TypeAction && element != TypeNil {
+				rules = append(rules, rule.String())
+			}
+		}
+	}
+	return
+}
+
+/* Repl parses each line read from in with the grammar, printing the syntax tree, or where and why the parse
+   failed, to out. The grammar is read again with load when the line is :reload. */
+func Repl(in io.Reader, out io.Writer, load func() (*Tree, error)) error {
+	t, err := load()
+	if err != nil {
+		return err
+	}
+	t.interpretable()
+	rules := t.definedRules()
+	if len(rules) == 0 {
+		return fmt.Errorf("the grammar has no rules")
+	}
+	rule, trace := rules[0], false
+	fmt.Fprintf(out, "starting with rule '%v', :help lists the commands\n", rule)
+
+	scanner := bufio.NewScanner(in)
+	for fmt.Fprint(out, "> "); scanner.Scan(); fmt.Fprint(out, "> ") {
+		line := scanner.Text()
+		command := strings.Fields(line)
+		if strings.HasPrefix(line, "::") {
+			line, command = line[1:], nil
+		}
+		if len(command) == 0 || !strings.HasPrefix(line, ":") {
+			var tracer io.Writer
+			if trace {
+				tracer = out
+			}
+			m, err := t.interpret(rule, line, tracer)
+			if err != nil {
+				if e, ok := err.(*runError); ok && !trace {
+					fmt.Fprintf(out, "  %v^\n", strings.Repeat(" ", e.symbol-1))
+				}
+				fmt.Fprintln(out, err)
+				continue
+			}
+			m.PrintSyntaxTree(out)
+			if length := len(m.buffer) - 1; m.position < length && !trace {
+				_, symbol := m.location(m.position)
+				fmt.Fprintf(out, "  %v^\nthe rule matched the line up to symbol %v\n", strings.Repeat(" ", symbol-1), symbol)
+			}
+			continue
+		}
+
+		switch command[0] {
+		case ":rule":
+			if len(command) == 1 {
+				for _, r := range rules {
+					if r == rule {
+						fmt.Fprintf(out, "%v (start)\n", r)
+					} else {
+						fmt.Fprintln(out, r)
+					}
+				}
+				break
+			}
+			defined := false
+			for _, r := range rules {
+				defined = defined || r == command[1]
+			}
+			if !defined {
+				fmt.Fprintf(out, "undefined rule '%v'\n", command[1])
+				break
+			}
+			rule = command[1]
+		case ":trace":
+			trace = !trace
+			if trace {
+				fmt.Fprintln(out, "tracing the calls of rules")
+			} else {
+				fmt.Fprintln(out, "not tracing")
+			}
+		case ":reload":
+			reloaded, err := load()
+			if err == nil && len(reloaded.definedRules()) == 0 {
+				err = fmt.Errorf("the grammar has no rules")
+			}
+			if err != nil {
+				fmt.Fprintln(out, err)
+				break
+			}
+			t, rules = reloaded, reloaded.definedRules()
+			t.interpretable()
+			defined := false
+			for _, r := range rules {
+				defined = defined || r == rule
+			}
+			if !defined {
+				rule = rules[0]
+				fmt.Fprintf(out, "starting with rule '%v'\n", rule)
+			}
+		case ":quit":
+			return nil
+		case ":help":
+			fmt.Fprint(out, replHelp)
+		default:
+			fmt.Fprintf(out, "unknown command %v, :help lists the commands\n", command[0])
+		}
+	}
+	fmt.Fprintln(out)
+	return scanner.Err()
+}
@@ -0,0 +1,518 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+/* A token added by the interpreter, at the depth a generated parser would add it. */
+type runToken struct {
+	rule              string
+	begin, end, depth int
+}
+
+/* The state saved at a choice point and restored when the choice fails. */
+type runState struct {
+	position, tokens, depth, captures int
+}
+
+/* The text matched by a label of the rule being matched. */
+type runCapture struct {
+	name       string
+	begin, end int
+}
+
+/* A parse failure, located at the furthest position where a terminal was tried. */
+type runError struct {
+	line, symbol int
+	expected     []string
+}
+
+func (e *runError) Error() string {
+	message := fmt.Sprintf("parse error at line %v symbol %v", e.line, e.symbol)
+	if len(e.expected) > 0 {
+		message += ", expected " + strings.Join(e.expected, " or ")
+	}
+	return message
+}
+
+/* Matches the rules of a prepared tree over a buffer, the same way the code compiled from them does. */
+type interpreter struct {
+	*Tree
+	buffer          []rune
+	position, depth int
+	tokens          []runToken
+	/* the labels of the rules being matched, those of the innermost rule start at frame */
+	captures []runCapture
+	frame    int
+	/* the rules expanded in place within a lexer rule */
+	lexing map[string]bool
+	/* expectations aren't recorded within a !predicate */
+	negated  int
+	furthest int
+	expected []string
+	/* the calls of rules are printed to trace like a parser generated with -trace prints them */
+	trace io.Writer
+}
+
+func (m *interpreter) save() runState {
+	return runState{m.position, len(m.tokens), m.depth, len(m.captures)}
+}
+
+func (m *interpreter) restore(s runState) {
+	m.position, m.tokens, m.depth, m.captures = s.position, m.tokens[:s.tokens], s.depth, m.captures[:s.captures]
+}
+
+func (m *interpreter) add(rule string, begin int) {
+	m.tokens = append(m.tokens, runToken{rule: rule, begin: begin, end: m.position, depth: m.depth})
+}
+
+/* Opens the scope of the labels of a rule, returning the scope it hides. */
+func (m *interpreter) scope() int {
+	frame := m.frame
+	m.frame = len(m.captures)
+	return frame
+}
+
+func (m *interpreter) unscope(frame int) {
+	m.captures, m.frame = m.captures[:m.frame], frame
+}
+
+/* Records what was expected at the current position, keeping the expectations of the furthest one. */
+func (m *interpreter) expect(what string) {
+	if m.negated > 0 || m.position < m.furthest {
+		return
+	}
+	if m.position > m.furthest {
+		m.furthest, m.expected = m.position, nil
+	}
+	for _, e := range m.expected {
+		if e == what {
+			return
+		}
+	}
+	m.expected = append(m.expected, what)
+}
+
+func (m *interpreter) character() rune {
+	if m.position >= len(m.buffer) {
+		return m.EndSymbol
+	}
+	return m.buffer[m.position]
+}
+
+/* Matches an expression at a choice point, restoring the state when it fails. A commit
+   within the expression makes its failure the failure of the choice. */
+func (m *interpreter) choose(n Node, commits bool) (matched, committed bool) {
+	state := m.save()
+	var commit *bool
+	if commits {
+		commit = new(bool)
+	}
+	if m.match(n, commit) {
+		return true, false
+	}
+	if commit != nil && *commit {
+		return false, true
+	}
+	m.restore(state)
+	return false, false
+}
+
+/* The line and symbol of a position, both counted from 1. */
+func (m *interpreter) location(position int) (line, symbol int) {
+	line, symbol = 1, 1
+	for _, c := range m.buffer[:position] {
+		if c == '\n' {
+			line, symbol = line+1, 1
+		} else {
+			symbol++
+		}
+	}
+	return
+}
+
+/* Calls a rule the way its function in a generated parser is called. */
+func (m *interpreter) call(rule Node) bool {
+	state, lexing := m.save(), m.lexing
+	traced, indent := false, strings.Repeat(" ", m.depth)
+	if expression := rule.Front(); m.trace != nil && expression.GetType() == TypeImplicitPush {
+		if element := expression.Front().GetType(); element != TypeAction && element != TypeNil {
+			traced = true
+			fmt.Fprintf(m.trace, "%v%v at %v\n", indent, rule, m.position)
+		}
+	}
+	m.lexing = nil
+	matched := m.match(rule.Front(), nil)
+	m.lexing = lexing
+	if traced && matched {
+		fmt.Fprintf(m.trace, "%v%v matched %v\n", indent, rule, strconv.Quote(string(m.buffer[state.position:m.position])))
+	} else if traced {
+		fmt.Fprintf(m.trace, "%v%v failed\n", indent, rule)
+	}
+	if !matched {
+		m.restore(state)
+	}
+	return matched
+}
+
+/* Matches an expression, setting the commit of the enclosing choice if it passes a commit. */
+func (m *interpreter) match(n Node, commit *bool) bool {
+	switch n.GetType() {
+	case TypeDot:
+		if m.character() == m.EndSymbol {
+			m.expect("any character")
+			return false
+		}
+		m.position++
+	case TypeName:
+		name := n.String()
+		rule := m.Rules[name]
+		if m.lexing != nil {
+			switch expression := rule.Front().Front(); {
+			case expression.GetType() == TypeAction:
+				return true
+			case expression.GetType() != TypeNil && !m.lexing[name]:
+				m.lexing[name] = true
+				frame := m.scope()
+				matched := m.match(expression, nil)
+				m.unscope(frame)
+				delete(m.lexing, name)
+				return matched
+			}
+		}
+		return m.call(rule)
+	case TypeRange:
+		lower, _ := utf8.DecodeRuneInString(n.Front().String())
+		upper, _ := utf8.DecodeRuneInString(n.Front().Next().String())
+		if c := m.character(); c < lower || c > upper {
+			m.expect(fmt.Sprintf("[%v-%v]", escape(string(lower)), escape(string(upper))))
+			return false
+		}
+		m.position++
+	case TypeCharacter:
+		if c, _ := utf8.DecodeRuneInString(n.String()); m.character() != c {
+			m.expect(fmt.Sprintf("'%v'", escape(n.String())))
+			return false
+		}
+		m.position++
+	case TypeString:
+		position := m.position
+		for _, c := range n.String() {
+			if m.character() != c {
+				m.position = position
+				m.expect(strconv.Quote(n.String()))
+				return false
+			}
+			m.position++
+		}
+	case TypePredicate, TypeAction, TypeNil:
+	case TypeCommit:
+		if commit != nil {
+			*commit = true
+		}
+	case TypeBackReference:
+		for i := len(m.captures) - 1; i >= m.frame; i-- {
+			if capture := m.captures[i]; capture.name == n.String() {
+				position := m.position
+				for _, c := range m.buffer[capture.begin:capture.end] {
+					if m.character() != c {
+						m.position = position
+						m.expect("$" + n.String())
+						return false
+					}
+					m.position++
+				}
+				break
+			}
+		}
+	case TypePush, TypeLabel:
+		if m.lexing != nil {
+			begin := m.position
+			if !m.match(n.Front(), commit) {
+				return false
+			}
+			if n.GetType() == TypeLabel {
+				m.captures = append(m.captures, runCapture{n.String(), begin, m.position})
+			}
+			return true
+		}
+		fallthrough
+	case TypeImplicitPush:
+		element, begin := n.Front(), m.position
+		rule := element.Next().String()
+		if n.GetType() == TypeImplicitPush {
+			defer m.unscope(m.scope())
+		}
+		switch {
+		case element.GetType() == TypeAction:
+			m.add(rule, begin)
+		case m.lexing == nil && m.lexer[rule]:
+			suppressed, tokens := m.suppressed[rule], len(m.tokens)
+			if !suppressed {
+				m.depth++
+			}
+			m.lexing = map[string]bool{rule: true}
+			matched := m.match(element, commit)
+			m.lexing = nil
+			if !matched {
+				return false
+			}
+			m.tokens = m.tokens[:tokens]
+			if !suppressed {
+				m.depth--
+				m.add(rule, begin)
+			}
+		case m.suppressed[rule]:
+			return m.match(element, commit)
+		default:
+			m.depth++
+			if !m.match(element, commit) {
+				return false
+			}
+			m.depth--
+			m.add(rule, begin)
+			if n.GetType() == TypeLabel {
+				m.captures = append(m.captures, runCapture{n.String(), begin, m.position})
+			}
+		}
+	case TypeRepeat:
+		min, max := repetitionBounds(n)
+		count, commits := 0, hasCommit(n.Front())
+		for ; max < 0 || count < max; count++ {
+			matched, committed := m.choose(n.Front(), commits)
+			if committed {
+				return false
+			} else if !matched {
+				break
+			}
+		}
+		if count < min {
+			return false
+		}
+	case TypePrecedence:
+		/* precedence climbing, an operator binds its operands when min is at most its level */
+		levels := n.Slice()[1:]
+		var climb func(min int) bool
+		climb = func(min int) bool {
+			begin, tokens, fail := m.position, len(m.tokens), m.save()
+			if !m.match(n.Front(), nil) {
+				m.restore(fail)
+				return false
+			}
+		climbing:
+			for {
+				save := m.save()
+				for l := len(levels) - 1; l >= min; l-- {
+					next := l + 1
+					if levels[l].String() == "right" {
+						next = l
+					}
+					for _, operator := range levels[l].Slice() {
+						/* an action ending the operator runs once the right operand has been matched */
+						elements, action := []*node{operator}, Node(nil)
+						if operator.GetType() == TypeSequence {
+							elements = operator.Slice()
+							last := elements[len(elements)-1]
+							if last.GetType() == TypeName && m.Rules[last.String()].Front().Front().GetType() == TypeAction {
+								elements, action = elements[:len(elements)-1], last
+							}
+						}
+						matched := true
+						for _, element := range elements {
+							if matched = m.match(element, nil); !matched {
+								break
+							}
+						}
+						if matched && climb(next) {
+							if action != nil {
+								m.match(action, nil)
+							}
+							for i := tokens; i < len(m.tokens); i++ {
+								m.tokens[i].depth++
+							}
+							m.add(n.String(), begin)
+							continue climbing
+						}
+						m.restore(save)
+					}
+				}
+				return true
+			}
+		}
+		return climb(0)
+	case TypeAlternate:
+		elements := n.Slice()
+		commits := false
+		for _, element := range elements[:len(elements)-1] {
+			commits = commits || hasCommit(element)
+		}
+		for _, element := range elements[:len(elements)-1] {
+			if matched, committed := m.choose(element, commits); matched {
+				return true
+			} else if committed {
+				return false
+			}
+		}
+		return m.match(elements[len(elements)-1], nil)
+	case TypeUnorderedAlternate:
+		elements := n.Slice()
+		elements, last := elements[:len(elements)-1], elements[len(elements)-1].Front().Next()
+		c := m.character()
+		for _, element := range elements {
+			for _, character := range element.Front().Front().Slice() {
+				if r, _ := utf8.DecodeRuneInString(character.String()); character.GetType() == TypeCharacter && r == c {
+					return m.match(element.Front().Next(), nil)
+				}
+			}
+		}
+		return m.match(last, nil)
+	case TypeSequence:
+		for _, element := range n.Slice() {
+			if !m.match(element, commit) {
+				return false
+			}
+		}
+	case TypePeekFor:
+		state := m.save()
+		if !m.match(n.Front(), nil) {
+			return false
+		}
+		m.restore(state)
+	case TypePeekNot:
+		state := m.save()
+		m.negated++
+		matched := m.match(n.Front(), nil)
+		m.negated--
+		m.restore(state)
+		if matched {
+			if n.Front().GetType() == TypeDot {
+				m.expect("end of input")
+			}
+			return false
+		}
+	case TypeQuery:
+		if _, committed := m.choose(n.Front(), hasCommit(n.Front())); committed {
+			return false
+		}
+	case TypeStar, TypePlus:
+		if n.GetType() == TypePlus && !m.match(n.Front(), nil) {
+			return false
+		}
+		commits := hasCommit(n.Front())
+		for {
+			matched, committed := m.choose(n.Front(), commits)
+			if committed {
+				return false
+			} else if !matched {
+				break
+			}
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "illegal node type: %v\n", n.GetType())
+		return false
+	}
+	return true
+}
+
+/* Warns about the parts of a grammar the interpreter can't run like a generated parser. */
+func (t *Tree) interpretable() {
+	t.prepare()
+	for _, rule := range t.RuleNames {
+		if rule.Front().GetType() == TypeImplicitPush && rule.Front().Front().GetType() == TypeNil {
+			if _, ok := t.rulesCount[rule.String()]; ok {
+				fmt.Fprintf(os.Stderr, "rule '%v' used but not defined\n", rule)
+			}
+		}
+		predicates := false
+		walk(rule.Front(), func(n Node) {
+			predicates = predicates || n.GetType() == TypePredicate
+		})
+		if predicates {
+			fmt.Fprintf(os.Stderr, "rule '%v' has semantic predicates, they are assumed to be true\n", rule)
+		}
+	}
+}
+
+/* Interprets the rules of the tree over the input, starting with the named rule or the first one,
+   printing the calls of rules to trace unless it is nil. */
+func (t *Tree) interpret(rule, input string, trace io.Writer) (*interpreter, error) {
+	t.prepare()
+	start := t.Rules[rule]
+	if rule == "" && len(t.RuleNames) > 0 {
+		start = t.RuleNames[0]
+	}
+	if start == nil {
+		return nil, fmt.Errorf("undefined rule '%v'", rule)
+	}
+
+	m := &interpreter{Tree: t, buffer: append([]rune(input), t.EndSymbol), trace: trace}
+	if !m.call(start) {
+		line, symbol := m.location(m.furthest)
+		return m, &runError{line: line, symbol: symbol, expected: m.expected}
+	}
+	return m, nil
+}
+
+/* Prints the tokens like the PrintSyntaxTree method of a generated parser, with the text
+   between tokens shown as Pre_, _In_ and _Suf tokens. */
+func (m *interpreter) PrintSyntaxTree(out io.Writer) {
+	type tree struct {
+		runToken
+		children []*tree
+	}
+	var stack []*tree
+	for _, token := range m.tokens {
+		t, i := &tree{runToken: token}, len(stack)
+		for i > 0 && stack[i-1].depth > token.depth {
+			i--
+		}
+		t.children, stack = append(t.children, stack[i:]...), append(stack[:i], t)
+	}
+	root := &tree{runToken: runToken{end: m.position}, children: stack}
+	if len(stack) == 1 {
+		root = stack[0]
+	} else if len(stack) > 1 {
+		root.begin = stack[0].begin
+	}
+
+	line := func(rule string, begin, end, level int) {
+		fmt.Fprintf(out, "%v\x1B[34m%v\x1B[m %v\n", strings.Repeat(" ", level), rule, strconv.Quote(string(m.buffer[begin:end])))
+	}
+	var print func(t *tree, level int)
+	print = func(t *tree, level int) {
+		for i, child := range t.children {
+			if i > 0 && t.children[i-1].end != child.begin {
+				line("_In_", t.children[i-1].end, child.begin, level)
+			} else if i == 0 && t.begin < child.begin {
+				line("Pre_", t.begin, child.begin, level)
+			}
+			line(child.rule, child.begin, child.end, level)
+			print(child, level+1)
+		}
+		if length := len(t.children); length > 0 && t.children[length-1].end != t.end {
+			line("_Suf", t.children[length-1].end, t.end, level)
+		}
+	}
+	print(root, 1)
+}
+
+/* Run interprets the grammar over the input and prints the syntax tree a generated parser would build,
+   after the calls of rules when tracing. */
+func (t *Tree) Run(out io.Writer, rule, input string) error {
+	t.interpretable()
+	var trace io.Writer
+	if t.Tracing {
+		trace = out
+	}
+	m, err := t.interpret(rule, input, trace)
+	if err != nil {
+		return err
+	}
+	m.PrintSyntaxTree(out)
+	return nil
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+/* Parses each quoted line of inputs.txt with the generated parser, writing its tokens or FAIL into results.txt. */
+const runDriver = `package %v
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"testing"
+)
+
+func TestPegRun(t *testing.T) {
+	in, err := os.Open("inputs.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+	out, err := os.Create("results.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(nil, 1 << 24)
+	for scanner.Scan() {
+		input, err := strconv.Unquote(scanner.Text())
+		if err != nil {
+			t.Fatal(err)
+		}
+		p := &%v{Buffer: input}
+		p.Init()
+		if err := p.Parse(); err != nil {
+			fmt.Fprintln(out, "FAIL")
+			continue
+		}
+		for token := range p.Tokens() {
+			fmt.Fprintf(out, "%%v %%v %%v %%v\n", Rul3s[token.Rule], token.begin, token.end, token.next)
+		}
+		fmt.Fprintln(out, "OK")
+	}
+}
+`
+
+/* Loads a grammar of the grammars directory into a new tree. */
+func loadGrammar(t *testing.T, file string, options Options) *Peg {
+	source, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parseGrammar(t, string(source), options)
+}
+
+/* Inputs derived from the grammar, along with a truncated and a corrupted copy of each, which the grammar
+   mostly rejects. */
+func runInputs(t *testing.T, file string) (inputs []string) {
+	var generated bytes.Buffer
+	if err := loadGrammar(t, file, Options{}).GenerateInputs(&generated, 20, 1, 16, ""); err != nil {
+		t.Fatal(err)
+	}
+	random := rand.New(rand.NewSource(1))
+	for _, line := range strings.Split(strings.TrimSpace(generated.String()), "\n") {
+		input, err := strconv.Unquote(line)
+		if err != nil {
+			t.Fatal(err)
+		}
+		inputs = append(inputs, input, input[:len(input)/2])
+		if corrupted := []byte(input); len(corrupted) > 0 {
+			corrupted[random.Intn(len(corrupted))] = "#@;)}\x00 "[random.Intn(7)]
+			inputs = append(inputs, string(corrupted))
+		}
+	}
+	return
+}
+
+/* The interpreter of -run is an oracle for the generated parsers: over inputs derived from the example
+   grammars and from the grammars of testdata, which use the features the examples don't, both accept the
+   same inputs and add the same tokens. */
+func TestRunMatchesGenerated(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the parsers of the example grammars")
+	}
+	grammars, err := filepath.Glob(filepath.Join("grammars", "*", "*.peg"))
+	if err != nil || len(grammars) == 0 {
+		t.Fatalf("no example grammars: %v", err)
+	}
+	features, err := filepath.Glob(filepath.Join("testdata", "*.peg"))
+	if err != nil || len(features) == 0 {
+		t.Fatalf("no grammars in testdata: %v", err)
+	}
+	grammars = append(grammars, features...)
+	for _, file := range grammars {
+		inputs := runInputs(t, file)
+		var expected strings.Builder
+		interpreted := loadGrammar(t, file, Options{})
+		interpreted.interpretable()
+		for _, input := range inputs {
+			m, err := interpreted.interpret("", input, nil)
+			if err != nil {
+				expected.WriteString("FAIL\n")
+				continue
+			}
+			for _, token := range m.tokens {
+				fmt.Fprintf(&expected, "%v %v %v %v\n", token.rule, token.begin, token.end, token.depth)
+			}
+			expected.WriteString("OK\n")
+		}
+
+		for _, options := range []Options{{}, {Inline: true, Switch: true}} {
+			directory := t.TempDir()
+			tree := loadGrammar(t, file, options).Tree
+			tree.prepare()
+			sources, _ := filepath.Glob(filepath.Join(filepath.Dir(file), "*.go"))
+			for _, source := range sources {
+				if strings.HasSuffix(source, ".peg.go") || strings.HasSuffix(source, "_test.go") {
+					continue
+				}
+				code, err := ioutil.ReadFile(source)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := ioutil.WriteFile(filepath.Join(directory, filepath.Base(source)), code, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			tree.Compile(filepath.Join(directory, filepath.Base(file)+".go"))
+			quoted := make([]string, len(inputs))
+			for i, input := range inputs {
+				quoted[i] = strconv.Quote(input) + "\n"
+			}
+			files := map[string]string{
+				"go.mod":          "module pegrun\n",
+				"inputs.txt":      strings.Join(quoted, ""),
+				"peg_run_test.go": fmt.Sprintf(runDriver, tree.PackageName, tree.StructName),
+			}
+			for name, content := range files {
+				if err := ioutil.WriteFile(filepath.Join(directory, name), []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			command := exec.Command("go", "test", "-count=1", "-run", "^TestPegRun$")
+			command.Dir = directory
+			if output, err := command.CombinedOutput(); err != nil {
+				t.Fatalf("%v with %+v: %v\n%s", file, options, err, output)
+			}
+			results, err := ioutil.ReadFile(filepath.Join(directory, "results.txt"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			generated, interpreted := strings.SplitAfter(string(results), "\n"), strings.SplitAfter(expected.String(), "\n")
+			accepted, same := 0, true
+			for i, input := 0, 0; same && (i < len(generated) || i < len(interpreted)); i++ {
+				if same = i < len(generated) && i < len(interpreted) && generated[i] == interpreted[i]; !same {
+					t.Errorf("%v with %+v: input %q: the parser gave %q where the interpreter gave %q", file, options,
+						inputs[input], line(generated, i), line(interpreted, i))
+					break
+				}
+				if generated[i] == "OK\n" {
+					accepted++
+				}
+				if generated[i] == "OK\n" || generated[i] == "FAIL\n" {
+					input++
+				}
+			}
+			if same && (accepted == 0 || accepted == len(inputs)) {
+				t.Errorf("%v: %v of the %v inputs were accepted, the inputs don't test both", file, accepted, len(inputs))
+			}
+		}
+	}
+}
+
+func line(lines []string, i int) string {
+	if i < len(lines) {
+		return lines[i]
+	}
+	return ""
+}
@@ -0,0 +1,1132 @@
+// Code generated by peg expressions.peg; DO NOT EDIT.
+// peg version dev sha256 96c07de0d41abad34642632aeec82129804b40cb98c40c3864731f163b1fc6a2
+
+package expressions
+
+import (
+	/*"bytes"*/
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+const END_SYMBOL rune = 4
+
+/* The rule types inferred from the grammar are below. */
+type Rule uint8
+
+const (
+	RuleUnknown Rule = iota
+	Rulestatement
+	Rulesum
+	Rulevalue
+	Rulenumber
+	Rulename
+	Rulespacing
+	Rulecomment
+
+	RulePre_
+	Rule_In_
+	Rule_Suf
+)
+
+var Rul3s = [...]string{
+	"Unknown",
+	"statement",
+	"sum",
+	"value",
+	"number",
+	"name",
+	"spacing",
+	"comment",
+
+	"Pre_",
+	"_In_",
+	"_Suf",
+}
+
+type TokenTree interface {
+	Print()
+	PrintSyntax()
+	PrintSyntaxTree(buffer string)
+	Add(rule Rule, begin, end, next, depth int)
+	Expand(index int) TokenTree
+	Tokens() <-chan token32
+	Error() []token32
+	trim(length int)
+	deepen(begin, end int)
+}
+
+/* ${@} bit structure for abstract syntax tree */
+type token16 struct {
+	Rule
+	begin, end, next int16
+}
+
+func (t *token16) isZero() bool {
+	return t.Rule == RuleUnknown && t.begin == 0 && t.end == 0 && t.next == 0
+}
+
+func (t *token16) isParentOf(u token16) bool {
+	return t.begin <= u.begin && t.end >= u.end && t.next > u.next
+}
+
+func (t *token16) GetToken32() token32 {
+	return token32{Rule: t.Rule, begin: int32(t.begin), end: int32(t.end), next: int32(t.next)}
+}
+
+func (t *token16) String() string {
+	return fmt.Sprintf("\x1B[34m%v\x1B[m %v %v %v", Rul3s[t.Rule], t.begin, t.end, t.next)
+}
+
+type tokens16 struct {
+	tree    []token16
+	ordered [][]token16
+}
+
+func (t *tokens16) trim(length int) {
+	t.tree = t.tree[0:length]
+	t.ordered = nil
+}
+
+/* Moves the tokens from begin up to end a level down, below a token covering them. */
+func (t *tokens16) deepen(begin, end int) {
+	for i := begin; i < end; i++ {
+		t.tree[i].next++
+	}
+}
+
+func (t *tokens16) Print() {
+	for _, token := range t.tree {
+		fmt.Println(token.String())
+	}
+}
+
+func (t *tokens16) Order() [][]token16 {
+	if t.ordered != nil {
+		return t.ordered
+	}
+
+	depths := make([]int16, 1, math.MaxInt16)
+	for i, token := range t.tree {
+		if token.Rule == RuleUnknown {
+			t.tree = t.tree[:i]
+			break
+		}
+		depth := int(token.next)
+		if length := len(depths); depth >= length {
+			depths = depths[:depth+1]
+		}
+		depths[depth]++
+	}
+	depths = append(depths, 0)
+
+	ordered, pool := make([][]token16, len(depths)), make([]token16, len(t.tree)+len(depths))
+	for i, depth := range depths {
+		depth++
+		ordered[i], pool, depths[i] = pool[:depth], pool[depth:], 0
+	}
+
+	for i, token := range t.tree {
+		depth := token.next
+		token.next = int16(i)
+		ordered[depth][depths[depth]] = token
+		depths[depth]++
+	}
+	t.ordered = ordered
+	return ordered
+}
+
+type State16 struct {
+	token16
+	depths []int16
+	leaf   bool
+}
+
+func (t *tokens16) PreOrder() (<-chan State16, [][]token16) {
+	s, ordered := make(chan State16, 6), t.Order()
+	go func() {
+		var states [8]State16
+		for i, _ := range states {
+			states[i].depths = make([]int16, len(ordered))
+		}
+		depths, state, depth := make([]int16, len(ordered)), 0, 1
+		write := func(t token16, leaf bool) {
+			S := states[state]
+			state, S.Rule, S.begin, S.end, S.next, S.leaf = (state+1)%8, t.Rule, t.begin, t.end, int16(depth), leaf
+			copy(S.depths, depths)
+			s <- S
+		}
+
+		states[state].token16 = ordered[0][0]
+		depths[0]++
+		state++
+		a, b := ordered[depth-1][depths[depth-1]-1], ordered[depth][depths[depth]]
+	depthFirstSearch:
+		for {
+			for {
+				if i := depths[depth]; i > 0 {
+					if c, j := ordered[depth][i-1], depths[depth-1]; a.isParentOf(c) &&
+						(j < 2 || !ordered[depth-1][j-2].isParentOf(c)) {
+						if c.end != b.begin {
+							write(token16{Rule: Rule_In_, begin: c.end, end: b.begin}, true)
+						}
+						break
+					}
+				}
+
+				if a.begin < b.begin {
+					write(token16{Rule: RulePre_, begin: a.begin, end: b.begin}, true)
+				}
+				break
+			}
+
+			next := depth + 1
+			if c := ordered[next][depths[next]]; c.Rule != RuleUnknown && b.isParentOf(c) {
+				write(b, false)
+				depths[depth]++
+				depth, a, b = next, b, c
+				continue
+			}
+
+			write(b, true)
+			depths[depth]++
+			c, parent := ordered[depth][depths[depth]], true
+			for {
+				if c.Rule != RuleUnknown && a.isParentOf(c) {
+					b = c
+					continue depthFirstSearch
+				} else if parent && b.end != a.end {
+					write(token16{Rule: Rule_Suf, begin: b.end, end: a.end}, true)
+				}
+
+				depth--
+				if depth > 0 {
+					a, b, c = ordered[depth-1][depths[depth-1]-1], a, ordered[depth][depths[depth]]
+					parent = a.isParentOf(b)
+					continue
+				}
+
+				break depthFirstSearch
+			}
+		}
+
+		close(s)
+	}()
+	return s, ordered
+}
+
+func (t *tokens16) PrintSyntax() {
+	tokens, ordered := t.PreOrder()
+	max := -1
+	for token := range tokens {
+		if !token.leaf {
+			fmt.Printf("%v", token.begin)
+			for i, leaf, depths := 0, int(token.next), token.depths; i < leaf; i++ {
+				fmt.Printf(" \x1B[36m%v\x1B[m", Rul3s[ordered[i][depths[i]-1].Rule])
+			}
+			fmt.Printf(" \x1B[36m%v\x1B[m\n", Rul3s[token.Rule])
+		} else if token.begin == token.end {
+			fmt.Printf("%v", token.begin)
+			for i, leaf, depths := 0, int(token.next), token.depths; i < leaf; i++ {
+				fmt.Printf(" \x1B[31m%v\x1B[m", Rul3s[ordered[i][depths[i]-1].Rule])
+			}
+			fmt.Printf(" \x1B[31m%v\x1B[m\n", Rul3s[token.Rule])
+		} else {
+			for c, end := token.begin, token.end; c < end; c++ {
+				if i := int(c); max+1 < i {
+					for j := max; j < i; j++ {
+						fmt.Printf("skip %v %v\n", j, token.String())
+					}
+					max = i
+				} else if i := int(c); i <= max {
+					for j := i; j <= max; j++ {
+						fmt.Printf("dupe %v %v\n", j, token.String())
+					}
+				} else {
+					max = int(c)
+				}
+				fmt.Printf("%v", c)
+				for i, leaf, depths := 0, int(token.next), token.depths; i < leaf; i++ {
+					fmt.Printf(" \x1B[34m%v\x1B[m", Rul3s[ordered[i][depths[i]-1].Rule])
+				}
+				fmt.Printf(" \x1B[34m%v\x1B[m\n", Rul3s[token.Rule])
+			}
+			fmt.Printf("\n")
+		}
+	}
+}
+
+func (t *tokens16) PrintSyntaxTree(buffer string) {
+	tokens, _ := t.PreOrder()
+	for token := range tokens {
+		for c := 0; c < int(token.next); c++ {
+			fmt.Printf(" ")
+		}
+		fmt.Printf("\x1B[34m%v\x1B[m %v\n", Rul3s[token.Rule], strconv.Quote(buffer[token.begin:token.end]))
+	}
+}
+
+func (t *tokens16) Add(rule Rule, begin, end, depth, index int) {
+	t.tree[index] = token16{Rule: rule, begin: int16(begin), end: int16(end), next: int16(depth)}
+}
+
+func (t *tokens16) Tokens() <-chan token32 {
+	s := make(chan token32, 16)
+	go func() {
+		for _, v := range t.tree {
+			s <- v.GetToken32()
+		}
+		close(s)
+	}()
+	return s
+}
+
+func (t *tokens16) Error() []token32 {
+	ordered := t.Order()
+	length := len(ordered)
+	tokens, length := make([]token32, length), length-1
+	for i, _ := range tokens {
+		o := ordered[length-i]
+		if len(o) > 1 {
+			tokens[i] = o[len(o)-2].GetToken32()
+		}
+	}
+	return tokens
+}
+
+/* ${@} bit structure for abstract syntax tree */
+type token32 struct {
+	Rule
+	begin, end, next int32
+}
+
+func (t *token32) isZero() bool {
+	return t.Rule == RuleUnknown && t.begin == 0 && t.end == 0 && t.next == 0
+}
+
+func (t *token32) isParentOf(u token32) bool {
+	return t.begin <= u.begin && t.end >= u.end && t.next > u.next
+}
+
+func (t *token32) GetToken32() token32 {
+	return token32{Rule: t.Rule, begin: int32(t.begin), end: int32(t.end), next: int32(t.next)}
+}
+
+func (t *token32) String() string {
+	return fmt.Sprintf("\x1B[34m%v\x1B[m %v %v %v", Rul3s[t.Rule], t.begin, t.end, t.next)
+}
+
+type tokens32 struct {
+	tree    []token32
+	ordered [][]token32
+}
+
+func (t *tokens32) trim(length int) {
+	t.tree = t.tree[0:length]
+	t.ordered = nil
+}
+
+/* Moves the tokens from begin up to end a level down, below a token covering them. */
+func (t *tokens32) deepen(begin, end int) {
+	for i := begin; i < end; i++ {
+		t.tree[i].next++
+	}
+}
+
+func (t *tokens32) Print() {
+	for _, token := range t.tree {
+		fmt.Println(token.String())
+	}
+}
+
+func (t *tokens32) Order() [][]token32 {
+	if t.ordered != nil {
+		return t.ordered
+	}
+
+	depths := make([]int32, 1, math.MaxInt16)
+	for i, token := range t.tree {
+		if token.Rule == RuleUnknown {
+			t.tree = t.tree[:i]
+			break
+		}
+		depth := int(token.next)
+		if length := len(depths); depth >= length {
+			depths = depths[:depth+1]
+		}
+		depths[depth]++
+	}
+	depths = append(depths, 0)
+
+	ordered, pool := make([][]token32, len(depths)), make([]token32, len(t.tree)+len(depths))
+	for i, depth := range depths {
+		depth++
+		ordered[i], pool, depths[i] = pool[:depth], pool[depth:], 0
+	}
+
+	for i, token := range t.tree {
+		depth := token.next
+		token.next = int32(i)
+		ordered[depth][depths[depth]] = token
+		depths[depth]++
+	}
+	t.ordered = ordered
+	return ordered
+}
+
+type State32 struct {
+	token32
+	depths []int32
+	leaf   bool
+}
+
+func (t *tokens32) PreOrder() (<-chan State32, [][]token32) {
+	s, ordered := make(chan State32, 6), t.Order()
+	go func() {
+		var states [8]State32
+		for i, _ := range states {
+			states[i].depths = make([]int32, len(ordered))
+		}
+		depths, state, depth := make([]int32, len(ordered)), 0, 1
+		write := func(t token32, leaf bool) {
+			S := states[state]
+			state, S.Rule, S.begin, S.end, S.next, S.leaf = (state+1)%8, t.Rule, t.begin, t.end, int32(depth), leaf
+			copy(S.depths, depths)
+			s <- S
+		}
+
+		states[state].token32 = ordered[0][0]
+		depths[0]++
+		state++
+		a, b := ordered[depth-1][depths[depth-1]-1], ordered[depth][depths[depth]]
+	depthFirstSearch:
+		for {
+			for {
+				if i := depths[depth]; i > 0 {
+					if c, j := ordered[depth][i-1], depths[depth-1]; a.isParentOf(c) &&
+						(j < 2 || !ordered[depth-1][j-2].isParentOf(c)) {
+						if c.end != b.begin {
+							write(token32{Rule: Rule_In_, begin: c.end, end: b.begin}, true)
+						}
+						break
+					}
+				}
+
+				if a.begin < b.begin {
+					write(token32{Rule: RulePre_, begin: a.begin, end: b.begin}, true)
+				}
+				break
+			}
+
+			next := depth + 1
+			if c := ordered[next][depths[next]]; c.Rule != RuleUnknown && b.isParentOf(c) {
+				write(b, false)
+				depths[depth]++
+				depth, a, b = next, b, c
+				continue
+			}
+
+			write(b, true)
+			depths[depth]++
+			c, parent := ordered[depth][depths[depth]], true
+			for {
+				if c.Rule != RuleUnknown && a.isParentOf(c) {
+					b = c
+					continue depthFirstSearch
+				} else if parent && b.end != a.end {
+					write(token32{Rule: Rule_Suf, begin: b.end, end: a.end}, true)
+				}
+
+				depth--
+				if depth > 0 {
+					a, b, c = ordered[depth-1][depths[depth-1]-1], a, ordered[depth][depths[depth]]
+					parent = a.isParentOf(b)
+					continue
+				}
+
+				break depthFirstSearch
+			}
+		}
+
+		close(s)
+	}()
+	return s, ordered
+}
+
+func (t *tokens32) PrintSyntax() {
+	tokens, ordered := t.PreOrder()
+	max := -1
+	for token := range tokens {
+		if !token.leaf {
+			fmt.Printf("%v", token.begin)
+			for i, leaf, depths := 0, int(token.next), token.depths; i < leaf; i++ {
+				fmt.Printf(" \x1B[36m%v\x1B[m", Rul3s[ordered[i][depths[i]-1].Rule])
+			}
+			fmt.Printf(" \x1B[36m%v\x1B[m\n", Rul3s[token.Rule])
+		} else if token.begin == token.end {
+			fmt.Printf("%v", token.begin)
+			for i, leaf, depths := 0, int(token.next), token.depths; i < leaf; i++ {
+				fmt.Printf(" \x1B[31m%v\x1B[m", Rul3s[ordered[i][depths[i]-1].Rule])
+			}
+			fmt.Printf(" \x1B[31m%v\x1B[m\n", Rul3s[token.Rule])
+		} else {
+			for c, end := token.begin, token.end; c < end; c++ {
+				if i := int(c); max+1 < i {
+					for j := max; j < i; j++ {
+						fmt.Printf("skip %v %v\n", j, token.String())
+					}
+					max = i
+				} else if i := int(c); i <= max {
+					for j := i; j <= max; j++ {
+						fmt.Printf("dupe %v %v\n", j, token.String())
+					}
+				} else {
+					max = int(c)
+				}
+				fmt.Printf("%v", c)
+				for i, leaf, depths := 0, int(token.next), token.depths; i < leaf; i++ {
+					fmt.Printf(" \x1B[34m%v\x1B[m", Rul3s[ordered[i][depths[i]-1].Rule])
+				}
+				fmt.Printf(" \x1B[34m%v\x1B[m\n", Rul3s[token.Rule])
+			}
+			fmt.Printf("\n")
+		}
+	}
+}
+
+func (t *tokens32) PrintSyntaxTree(buffer string) {
+	tokens, _ := t.PreOrder()
+	for token := range tokens {
+		for c := 0; c < int(token.next); c++ {
+			fmt.Printf(" ")
+		}
+		fmt.Printf("\x1B[34m%v\x1B[m %v\n", Rul3s[token.Rule], strconv.Quote(buffer[token.begin:token.end]))
+	}
+}
+
+func (t *tokens32) Add(rule Rule, begin, end, depth, index int) {
+	t.tree[index] = token32{Rule: rule, begin: int32(begin), end: int32(end), next: int32(depth)}
+}
+
+func (t *tokens32) Tokens() <-chan token32 {
+	s := make(chan token32, 16)
+	go func() {
+		for _, v := range t.tree {
+			s <- v.GetToken32()
+		}
+		close(s)
+	}()
+	return s
+}
+
+func (t *tokens32) Error() []token32 {
+	ordered := t.Order()
+	length := len(ordered)
+	tokens, length := make([]token32, length), length-1
+	for i, _ := range tokens {
+		o := ordered[length-i]
+		if len(o) > 1 {
+			tokens[i] = o[len(o)-2].GetToken32()
+		}
+	}
+	return tokens
+}
+
+func (t *tokens16) Expand(index int) TokenTree {
+	tree := t.tree
+	if index < cap(tree) && index >= len(tree) {
+		t.tree = tree[:cap(tree)]
+	} else if index >= len(tree) {
+		expanded := make([]token32, 2*len(tree))
+		for i, v := range tree {
+			expanded[i] = v.GetToken32()
+		}
+		return &tokens32{tree: expanded}
+	}
+	return nil
+}
+
+func (t *tokens32) Expand(index int) TokenTree {
+	tree := t.tree
+	if index < cap(tree) && index >= len(tree) {
+		t.tree = tree[:cap(tree)]
+	} else if index >= len(tree) {
+		expanded := make([]token32, 2*len(tree))
+		copy(expanded, tree)
+		t.tree = expanded
+	}
+	return nil
+}
+
+type Expressions struct {
+	Buffer string
+	buffer []rune
+	rules  [8]func() bool
+	match  func(rule Rule, offset int) (int, bool)
+	Parse  func(rule ...int) error
+	Reset  func()
+
+	TokenTree
+}
+
+type textPosition struct {
+	line, symbol int
+}
+
+type textPositionMap map[int]textPosition
+
+func translatePositions(buffer string, positions []int) textPositionMap {
+	length, translations, j, line, symbol := len(positions), make(textPositionMap, len(positions)), 0, 1, 0
+	sort.Ints(positions)
+
+search:
+	for i, c := range buffer[0:] {
+		if c == '\n' {
+			line, symbol = line+1, 0
+		} else {
+			symbol++
+		}
+		if i == positions[j] {
+			translations[positions[j]] = textPosition{line, symbol}
+			for j++; j < length; j++ {
+				if i != positions[j] {
+					continue search
+				}
+			}
+			break search
+		}
+	}
+
+	return translations
+}
+
+type parseError struct {
+	p *Expressions
+}
+
+func (e *parseError) Error() string {
+	tokens, error := e.p.TokenTree.Error(), "\n"
+	positions, p := make([]int, 2*len(tokens)), 0
+	for _, token := range tokens {
+		positions[p], p = int(token.begin), p+1
+		positions[p], p = int(token.end), p+1
+	}
+	translations := translatePositions(e.p.Buffer, positions)
+	for _, token := range tokens {
+		begin, end := int(token.begin), int(token.end)
+		error += fmt.Sprintf("parse error near \x1B[34m%v\x1B[m (line %v symbol %v - line %v symbol %v):\n%v\n",
+			Rul3s[token.Rule],
+			translations[begin].line, translations[begin].symbol,
+			translations[end].line, translations[end].symbol,
+			/*strconv.Quote(*/ e.p.Buffer[begin:end] /*)*/)
+	}
+
+	return error
+}
+
+func (p *Expressions) PrintSyntaxTree() {
+	p.TokenTree.PrintSyntaxTree(p.Buffer)
+}
+
+func (p *Expressions) Highlighter() {
+	p.TokenTree.PrintSyntax()
+}
+
+/* ParseRule parses the buffer starting with the given rule, which must be used or public. */
+func (p *Expressions) ParseRule(rule Rule) error {
+	if rule == RuleUnknown || int(rule) >= len(p.rules) || p.rules[rule] == nil {
+		name := fmt.Sprint(int(rule))
+		if int(rule) < len(Rul3s) {
+			name = Rul3s[rule]
+		}
+		return fmt.Errorf("rule %v isn't an entry point", name)
+	}
+	return p.Parse(int(rule))
+}
+
+/* ParseString parses the input starting with the given rule. */
+func (p *Expressions) ParseString(rule Rule, input string) error {
+	p.Buffer = input
+	p.Init()
+	return p.ParseRule(rule)
+}
+
+/*
+Match matches the rule at the rune offset of the input, without requiring the rest of the
+
+	input to match, and returns the rune offset where the match ended. The rune buffer is only
+	rebuilt when the input changes, so a rule can be matched repeatedly along the same input.
+*/
+func (p *Expressions) Match(rule Rule, input string, offset int) (end int, ok bool) {
+	if p.match == nil || input != p.Buffer {
+		p.Buffer = input
+		p.Init()
+	}
+	if rule == RuleUnknown || int(rule) >= len(p.rules) || p.rules[rule] == nil ||
+		offset < 0 || offset >= len(p.buffer) {
+		return 0, false
+	}
+	return p.match(rule, offset)
+}
+
+func (p *Expressions) Init() {
+	p.buffer = []rune(p.Buffer)
+	if len(p.buffer) == 0 || p.buffer[len(p.buffer)-1] != END_SYMBOL {
+		p.buffer = append(p.buffer, END_SYMBOL)
+	}
+
+	var tree TokenTree = &tokens16{tree: make([]token16, math.MaxInt16)}
+	position, depth, tokenIndex, buffer, rules := 0, 0, 0, p.buffer, p.rules
+
+	p.Parse = func(rule ...int) error {
+		r := 1
+		if len(rule) > 0 {
+			r = rule[0]
+		}
+
+		matches := p.rules[r]()
+		p.TokenTree = tree
+		if matches {
+			p.TokenTree.trim(tokenIndex)
+
+			return nil
+		}
+		return &parseError{p}
+	}
+
+	p.Reset = func() {
+		position, tokenIndex, depth = 0, 0, 0
+
+	}
+
+	p.match = func(rule Rule, offset int) (int, bool) {
+		position, tokenIndex, depth = offset, 0, 0
+
+		matches := rules[rule]()
+		p.TokenTree = tree
+		if !matches {
+			return 0, false
+		}
+		p.TokenTree.trim(tokenIndex)
+		return position, true
+	}
+
+	add := func(rule Rule, begin int) {
+		if t := tree.Expand(tokenIndex); t != nil {
+			tree = t
+		}
+		tree.Add(rule, begin, position, depth, tokenIndex)
+
+		tokenIndex++
+	}
+
+	matchDot := func() bool {
+		if buffer[position] != END_SYMBOL {
+			position++
+			return true
+		}
+		return false
+	}
+
+	/*matchChar := func(c byte) bool {
+		if buffer[position] == c {
+			position++
+			return true
+		}
+		return false
+	}*/
+
+	/*matchRange := func(lower byte, upper byte) bool {
+		if c := buffer[position]; c >= lower && c <= upper {
+			position++
+			return true
+		}
+		return false
+	}*/
+
+	rules = [...]func() bool{
+		nil,
+		/* 0 statement <- <((((spacing ('l' 'e' 't')) ^ (spacing name) (spacing '=') sum) / sum) (spacing !.))> */
+		func() bool {
+			position0, tokenIndex0, depth0 := position, tokenIndex, depth
+			{
+				position1 := position
+				depth++
+				{
+					position2, tokenIndex2, depth2 := position, tokenIndex, depth
+					commit2 := false
+					if !rules[Rulespacing]() {
+						goto l3
+					}
+					if buffer[position] != rune('l') {
+						goto l3
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l3
+					}
+					position++
+					if buffer[position] != rune('t') {
+						goto l3
+					}
+					position++
+					commit2 = true
+					if !rules[Rulespacing]() {
+						goto l3
+					}
+					if !rules[Rulename]() {
+						goto l3
+					}
+					if !rules[Rulespacing]() {
+						goto l3
+					}
+					if buffer[position] != rune('=') {
+						goto l3
+					}
+					position++
+					if !rules[Rulesum]() {
+						goto l3
+					}
+					goto l2
+				l3:
+					if commit2 {
+						goto l0
+					}
+
+					position, tokenIndex, depth = position2, tokenIndex2, depth2
+					if !rules[Rulesum]() {
+						goto l0
+					}
+				}
+			l2:
+				if !rules[Rulespacing]() {
+					goto l0
+				}
+				{
+					position4, tokenIndex4, depth4 := position, tokenIndex, depth
+					if !matchDot() {
+						goto l4
+					}
+					goto l0
+				l4:
+					position, tokenIndex, depth = position4, tokenIndex4, depth4
+				}
+				depth--
+				add(Rulestatement, position1)
+			}
+			return true
+		l0:
+			position, tokenIndex, depth = position0, tokenIndex0, depth0
+			return false
+		},
+		/* 1 sum <- <value %left (spacing '+') / (spacing '-') %left (spacing '*') / (spacing '/') %right (spacing '^')> */
+		func() bool {
+			position5, tokenIndex5, depth5 := position, tokenIndex, depth
+			{
+				position6 := position
+				depth++
+				var climb7 func(min int) bool
+				climb7 = func(min int) bool {
+					position8, tokenIndex8 := position, tokenIndex
+					position9, tokenIndex9, depth9 := position, tokenIndex, depth
+					if !rules[Rulevalue]() {
+						goto l9
+					}
+					for {
+						position10, tokenIndex10, depth10 := position, tokenIndex, depth
+						if min > 2 {
+							goto l11
+						}
+						{
+							if !rules[Rulespacing]() {
+								goto l11
+							}
+							if buffer[position] != rune('^') {
+								goto l11
+							}
+							position++
+							if !climb7(2) {
+								goto l11
+							}
+							tree.deepen(tokenIndex8, tokenIndex)
+							add(Rulesum, position8)
+							continue
+						}
+					l11:
+						position, tokenIndex, depth = position10, tokenIndex10, depth10
+						if min > 1 {
+							goto l12
+						}
+						{
+							if !rules[Rulespacing]() {
+								goto l12
+							}
+							if buffer[position] != rune('*') {
+								goto l12
+							}
+							position++
+							if !climb7(2) {
+								goto l12
+							}
+							tree.deepen(tokenIndex8, tokenIndex)
+							add(Rulesum, position8)
+							continue
+						}
+					l12:
+						position, tokenIndex, depth = position10, tokenIndex10, depth10
+						if min > 1 {
+							goto l13
+						}
+						{
+							if !rules[Rulespacing]() {
+								goto l13
+							}
+							if buffer[position] != rune('/') {
+								goto l13
+							}
+							position++
+							if !climb7(2) {
+								goto l13
+							}
+							tree.deepen(tokenIndex8, tokenIndex)
+							add(Rulesum, position8)
+							continue
+						}
+					l13:
+						position, tokenIndex, depth = position10, tokenIndex10, depth10
+						if min > 0 {
+							goto l14
+						}
+						{
+							if !rules[Rulespacing]() {
+								goto l14
+							}
+							if buffer[position] != rune('+') {
+								goto l14
+							}
+							position++
+							if !climb7(1) {
+								goto l14
+							}
+							tree.deepen(tokenIndex8, tokenIndex)
+							add(Rulesum, position8)
+							continue
+						}
+					l14:
+						position, tokenIndex, depth = position10, tokenIndex10, depth10
+						if min > 0 {
+							goto l15
+						}
+						{
+							if !rules[Rulespacing]() {
+								goto l15
+							}
+							if buffer[position] != rune('-') {
+								goto l15
+							}
+							position++
+							if !climb7(1) {
+								goto l15
+							}
+							tree.deepen(tokenIndex8, tokenIndex)
+							add(Rulesum, position8)
+							continue
+						}
+					l15:
+						position, tokenIndex, depth = position10, tokenIndex10, depth10
+						return true
+					}
+				l9:
+					position, tokenIndex, depth = position9, tokenIndex9, depth9
+					return false
+				}
+				if !climb7(0) {
+					goto l5
+				}
+				depth--
+				add(Rulesum, position6)
+			}
+			return true
+		l5:
+			position, tokenIndex, depth = position5, tokenIndex5, depth5
+			return false
+		},
+		/* 2 value <- <((spacing number) / (spacing name) / ((spacing '(') sum (spacing ')')))> */
+		func() bool {
+			position16, tokenIndex16, depth16 := position, tokenIndex, depth
+			{
+				position17 := position
+				depth++
+				{
+					position18, tokenIndex18, depth18 := position, tokenIndex, depth
+					if !rules[Rulespacing]() {
+						goto l19
+					}
+					if !rules[Rulenumber]() {
+						goto l19
+					}
+					goto l18
+				l19:
+					position, tokenIndex, depth = position18, tokenIndex18, depth18
+					if !rules[Rulespacing]() {
+						goto l20
+					}
+					if !rules[Rulename]() {
+						goto l20
+					}
+					goto l18
+				l20:
+					position, tokenIndex, depth = position18, tokenIndex18, depth18
+					if !rules[Rulespacing]() {
+						goto l16
+					}
+					if buffer[position] != rune('(') {
+						goto l16
+					}
+					position++
+					if !rules[Rulesum]() {
+						goto l16
+					}
+					if !rules[Rulespacing]() {
+						goto l16
+					}
+					if buffer[position] != rune(')') {
+						goto l16
+					}
+					position++
+				}
+			l18:
+				depth--
+				add(Rulevalue, position17)
+			}
+			return true
+		l16:
+			position, tokenIndex, depth = position16, tokenIndex16, depth16
+			return false
+		},
+		/* 3 number <- <[0-9]{1,4}> */
+		func() bool {
+			position21, tokenIndex21, depth21 := position, tokenIndex, depth
+			{
+				position22, tokenIndex22 := position, tokenIndex
+				depth++
+				{
+					count23 := 0
+				l23:
+					if count23 < 4 {
+						{
+							position24, tokenIndex24, depth24 := position, tokenIndex, depth
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l24
+							}
+							position++
+							count23++
+							goto l23
+						l24:
+							position, tokenIndex, depth = position24, tokenIndex24, depth24
+						}
+					}
+					if count23 < 1 {
+						goto l21
+					}
+				}
+				tokenIndex = tokenIndex22
+				depth--
+				add(Rulenumber, position22)
+			}
+			return true
+		l21:
+			position, tokenIndex, depth = position21, tokenIndex21, depth21
+			return false
+		},
+		/* 4 name <- <[a-z]+> */
+		func() bool {
+			position25, tokenIndex25, depth25 := position, tokenIndex, depth
+			{
+				position26, tokenIndex26 := position, tokenIndex
+				depth++
+				if c := buffer[position]; c < rune('a') || c > rune('z') {
+					goto l25
+				}
+				position++
+			l27:
+				{
+					position28, tokenIndex28, depth28 := position, tokenIndex, depth
+					if c := buffer[position]; c < rune('a') || c > rune('z') {
+						goto l28
+					}
+					position++
+					goto l27
+				l28:
+					position, tokenIndex, depth = position28, tokenIndex28, depth28
+				}
+				tokenIndex = tokenIndex26
+				depth--
+				add(Rulename, position26)
+			}
+			return true
+		l25:
+			position, tokenIndex, depth = position25, tokenIndex25, depth25
+			return false
+		},
+		/* 5 spacing <- <(' ' / comment)*> */
+		func() bool {
+			{
+			l31:
+				{
+					position32, tokenIndex32, depth32 := position, tokenIndex, depth
+					{
+						position33, tokenIndex33, depth33 := position, tokenIndex, depth
+						if buffer[position] != rune(' ') {
+							goto l34
+						}
+						position++
+						goto l33
+					l34:
+						position, tokenIndex, depth = position33, tokenIndex33, depth33
+						if !rules[Rulecomment]() {
+							goto l32
+						}
+					}
+				l33:
+					goto l31
+				l32:
+					position, tokenIndex, depth = position32, tokenIndex32, depth32
+				}
+			}
+			return true
+		},
+		/* 6 comment <- <('#' [a-z]*)> */
+		func() bool {
+			position35, tokenIndex35, depth35 := position, tokenIndex, depth
+			{
+				position36 := position
+				depth++
+				if buffer[position] != rune('#') {
+					goto l35
+				}
+				position++
+			l37:
+				{
+					position38, tokenIndex38, depth38 := position, tokenIndex, depth
+					if c := buffer[position]; c < rune('a') || c > rune('z') {
+						goto l38
+					}
+					position++
+					goto l37
+				l38:
+					position, tokenIndex, depth = position38, tokenIndex38, depth38
+				}
+				depth--
+				add(Rulecomment, position36)
+			}
+			return true
+		l35:
+			position, tokenIndex, depth = position35, tokenIndex35, depth35
+			return false
+		},
+	}
+	p.rules = rules
+}
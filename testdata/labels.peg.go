@@ -0,0 +1,1179 @@
+// Code generated by peg labels.peg; DO NOT EDIT.
+// peg version dev sha256 1b4fcafc718b5d81f5aca33dc44288cf04e02c56f9c7e8ffa0c1e31c884531d5
+
+package labels
+
+import (
+	/*"bytes"*/
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"unicode/utf8"
+)
+
+const END_SYMBOL rune = 4
+
+/* The rule types inferred from the grammar are below. */
+type Rule uint8
+
+const (
+	RuleUnknown Rule = iota
+	Ruledocument
+	Ruleheredoc
+	Rulepair
+	RuleLabel_tag
+	RulePegText
+	RuleLabel_key
+	RuleLabel_value
+	RuleAction0
+
+	RulePre_
+	Rule_In_
+	Rule_Suf
+)
+
+var Rul3s = [...]string{
+	"Unknown",
+	"document",
+	"heredoc",
+	"pair",
+	"Label_tag",
+	"PegText",
+	"Label_key",
+	"Label_value",
+	"Action0",
+
+	"Pre_",
+	"_In_",
+	"_Suf",
+}
+
+type TokenTree interface {
+	Print()
+	PrintSyntax()
+	PrintSyntaxTree(buffer string)
+	Add(rule Rule, begin, end, next, depth int)
+	Expand(index int) TokenTree
+	Tokens() <-chan token32
+	Error() []token32
+	trim(length int)
+}
+
+/* ${@} bit structure for abstract syntax tree */
+type token16 struct {
+	Rule
+	begin, end, next int16
+}
+
+func (t *token16) isZero() bool {
+	return t.Rule == RuleUnknown && t.begin == 0 && t.end == 0 && t.next == 0
+}
+
+func (t *token16) isParentOf(u token16) bool {
+	return t.begin <= u.begin && t.end >= u.end && t.next > u.next
+}
+
+func (t *token16) GetToken32() token32 {
+	return token32{Rule: t.Rule, begin: int32(t.begin), end: int32(t.end), next: int32(t.next)}
+}
+
+func (t *token16) String() string {
+	return fmt.Sprintf("\x1B[34m%v\x1B[m %v %v %v", Rul3s[t.Rule], t.begin, t.end, t.next)
+}
+
+type tokens16 struct {
+	tree    []token16
+	ordered [][]token16
+}
+
+func (t *tokens16) trim(length int) {
+	t.tree = t.tree[0:length]
+	t.ordered = nil
+}
+
+func (t *tokens16) Print() {
+	for _, token := range t.tree {
+		fmt.Println(token.String())
+	}
+}
+
+func (t *tokens16) Order() [][]token16 {
+	if t.ordered != nil {
+		return t.ordered
+	}
+
+	depths := make([]int16, 1, math.MaxInt16)
+	for i, token := range t.tree {
+		if token.Rule == RuleUnknown {
+			t.tree = t.tree[:i]
+			break
+		}
+		depth := int(token.next)
+		if length := len(depths); depth >= length {
+			depths = depths[:depth+1]
+		}
+		depths[depth]++
+	}
+	depths = append(depths, 0)
+
+	ordered, pool := make([][]token16, len(depths)), make([]token16, len(t.tree)+len(depths))
+	for i, depth := range depths {
+		depth++
+		ordered[i], pool, depths[i] = pool[:depth], pool[depth:], 0
+	}
+
+	for i, token := range t.tree {
+		depth := token.next
+		token.next = int16(i)
+		ordered[depth][depths[depth]] = token
+		depths[depth]++
+	}
+	t.ordered = ordered
+	return ordered
+}
+
+type State16 struct {
+	token16
+	depths []int16
+	leaf   bool
+}
+
+func (t *tokens16) PreOrder() (<-chan State16, [][]token16) {
+	s, ordered := make(chan State16, 6), t.Order()
+	go func() {
+		var states [8]State16
+		for i, _ := range states {
+			states[i].depths = make([]int16, len(ordered))
+		}
+		depths, state, depth := make([]int16, len(ordered)), 0, 1
+		write := func(t token16, leaf bool) {
+			S := states[state]
+			state, S.Rule, S.begin, S.end, S.next, S.leaf = (state+1)%8, t.Rule, t.begin, t.end, int16(depth), leaf
+			copy(S.depths, depths)
+			s <- S
+		}
+
+		states[state].token16 = ordered[0][0]
+		depths[0]++
+		state++
+		a, b := ordered[depth-1][depths[depth-1]-1], ordered[depth][depths[depth]]
+	depthFirstSearch:
+		for {
+			for {
+				if i := depths[depth]; i > 0 {
+					if c, j := ordered[depth][i-1], depths[depth-1]; a.isParentOf(c) &&
+						(j < 2 || !ordered[depth-1][j-2].isParentOf(c)) {
+						if c.end != b.begin {
+							write(token16{Rule: Rule_In_, begin: c.end, end: b.begin}, true)
+						}
+						break
+					}
+				}
+
+				if a.begin < b.begin {
+					write(token16{Rule: RulePre_, begin: a.begin, end: b.begin}, true)
+				}
+				break
+			}
+
+			next := depth + 1
+			if c := ordered[next][depths[next]]; c.Rule != RuleUnknown && b.isParentOf(c) {
+				write(b, false)
+				depths[depth]++
+				depth, a, b = next, b, c
+				continue
+			}
+
+			write(b, true)
+			depths[depth]++
+			c, parent := ordered[depth][depths[depth]], true
+			for {
+				if c.Rule != RuleUnknown && a.isParentOf(c) {
+					b = c
+					continue depthFirstSearch
+				} else if parent && b.end != a.end {
+					write(token16{Rule: Rule_Suf, begin: b.end, end: a.end}, true)
+				}
+
+				depth--
+				if depth > 0 {
+					a, b, c = ordered[depth-1][depths[depth-1]-1], a, ordered[depth][depths[depth]]
+					parent = a.isParentOf(b)
+					continue
+				}
+
+				break depthFirstSearch
+			}
+		}
+
+		close(s)
+	}()
+	return s, ordered
+}
+
+func (t *tokens16) PrintSyntax() {
+	tokens, ordered := t.PreOrder()
+	max := -1
+	for token := range tokens {
+		if !token.leaf {
+			fmt.Printf("%v", token.begin)
+			for i, leaf, depths := 0, int(token.next), token.depths; i < leaf; i++ {
+				fmt.Printf(" \x1B[36m%v\x1B[m", Rul3s[ordered[i][depths[i]-1].Rule])
+			}
+			fmt.Printf(" \x1B[36m%v\x1B[m\n", Rul3s[token.Rule])
+		} else if token.begin == token.end {
+			fmt.Printf("%v", token.begin)
+			for i, leaf, depths := 0, int(token.next), token.depths; i < leaf; i++ {
+				fmt.Printf(" \x1B[31m%v\x1B[m", Rul3s[ordered[i][depths[i]-1].Rule])
+			}
+			fmt.Printf(" \x1B[31m%v\x1B[m\n", Rul3s[token.Rule])
+		} else {
+			for c, end := token.begin, token.end; c < end; c++ {
+				if i := int(c); max+1 < i {
+					for j := max; j < i; j++ {
+						fmt.Printf("skip %v %v\n", j, token.String())
+					}
+					max = i
+				} else if i := int(c); i <= max {
+					for j := i; j <= max; j++ {
+						fmt.Printf("dupe %v %v\n", j, token.String())
+					}
+				} else {
+					max = int(c)
+				}
+				fmt.Printf("%v", c)
+				for i, leaf, depths := 0, int(token.next), token.depths; i < leaf; i++ {
+					fmt.Printf(" \x1B[34m%v\x1B[m", Rul3s[ordered[i][depths[i]-1].Rule])
+				}
+				fmt.Printf(" \x1B[34m%v\x1B[m\n", Rul3s[token.Rule])
+			}
+			fmt.Printf("\n")
+		}
+	}
+}
+
+func (t *tokens16) PrintSyntaxTree(buffer string) {
+	tokens, _ := t.PreOrder()
+	for token := range tokens {
+		for c := 0; c < int(token.next); c++ {
+			fmt.Printf(" ")
+		}
+		fmt.Printf("\x1B[34m%v\x1B[m %v\n", Rul3s[token.Rule], strconv.Quote(buffer[token.begin:token.end]))
+	}
+}
+
+func (t *tokens16) Add(rule Rule, begin, end, depth, index int) {
+	t.tree[index] = token16{Rule: rule, begin: int16(begin), end: int16(end), next: int16(depth)}
+}
+
+func (t *tokens16) Tokens() <-chan token32 {
+	s := make(chan token32, 16)
+	go func() {
+		for _, v := range t.tree {
+			s <- v.GetToken32()
+		}
+		close(s)
+	}()
+	return s
+}
+
+func (t *tokens16) Error() []token32 {
+	ordered := t.Order()
+	length := len(ordered)
+	tokens, length := make([]token32, length), length-1
+	for i, _ := range tokens {
+		o := ordered[length-i]
+		if len(o) > 1 {
+			tokens[i] = o[len(o)-2].GetToken32()
+		}
+	}
+	return tokens
+}
+
+/* ${@} bit structure for abstract syntax tree */
+type token32 struct {
+	Rule
+	begin, end, next int32
+}
+
+func (t *token32) isZero() bool {
+	return t.Rule == RuleUnknown && t.begin == 0 && t.end == 0 && t.next == 0
+}
+
+func (t *token32) isParentOf(u token32) bool {
+	return t.begin <= u.begin && t.end >= u.end && t.next > u.next
+}
+
+func (t *token32) GetToken32() token32 {
+	return token32{Rule: t.Rule, begin: int32(t.begin), end: int32(t.end), next: int32(t.next)}
+}
+
+func (t *token32) String() string {
+	return fmt.Sprintf("\x1B[34m%v\x1B[m %v %v %v", Rul3s[t.Rule], t.begin, t.end, t.next)
+}
+
+type tokens32 struct {
+	tree    []token32
+	ordered [][]token32
+}
+
+func (t *tokens32) trim(length int) {
+	t.tree = t.tree[0:length]
+	t.ordered = nil
+}
+
+func (t *tokens32) Print() {
+	for _, token := range t.tree {
+		fmt.Println(token.String())
+	}
+}
+
+func (t *tokens32) Order() [][]token32 {
+	if t.ordered != nil {
+		return t.ordered
+	}
+
+	depths := make([]int32, 1, math.MaxInt16)
+	for i, token := range t.tree {
+		if token.Rule == RuleUnknown {
+			t.tree = t.tree[:i]
+			break
+		}
+		depth := int(token.next)
+		if length := len(depths); depth >= length {
+			depths = depths[:depth+1]
+		}
+		depths[depth]++
+	}
+	depths = append(depths, 0)
+
+	ordered, pool := make([][]token32, len(depths)), make([]token32, len(t.tree)+len(depths))
+	for i, depth := range depths {
+		depth++
+		ordered[i], pool, depths[i] = pool[:depth], pool[depth:], 0
+	}
+
+	for i, token := range t.tree {
+		depth := token.next
+		token.next = int32(i)
+		ordered[depth][depths[depth]] = token
+		depths[depth]++
+	}
+	t.ordered = ordered
+	return ordered
+}
+
+type State32 struct {
+	token32
+	depths []int32
+	leaf   bool
+}
+
+func (t *tokens32) PreOrder() (<-chan State32, [][]token32) {
+	s, ordered := make(chan State32, 6), t.Order()
+	go func() {
+		var states [8]State32
+		for i, _ := range states {
+			states[i].depths = make([]int32, len(ordered))
+		}
+		depths, state, depth := make([]int32, len(ordered)), 0, 1
+		write := func(t token32, leaf bool) {
+			S := states[state]
+			state, S.Rule, S.begin, S.end, S.next, S.leaf = (state+1)%8, t.Rule, t.begin, t.end, int32(depth), leaf
+			copy(S.depths, depths)
+			s <- S
+		}
+
+		states[state].token32 = ordered[0][0]
+		depths[0]++
+		state++
+		a, b := ordered[depth-1][depths[depth-1]-1], ordered[depth][depths[depth]]
+	depthFirstSearch:
+		for {
+			for {
+				if i := depths[depth]; i > 0 {
+					if c, j := ordered[depth][i-1], depths[depth-1]; a.isParentOf(c) &&
+						(j < 2 || !ordered[depth-1][j-2].isParentOf(c)) {
+						if c.end != b.begin {
+							write(token32{Rule: Rule_In_, begin: c.end, end: b.begin}, true)
+						}
+						break
+					}
+				}
+
+				if a.begin < b.begin {
+					write(token32{Rule: RulePre_, begin: a.begin, end: b.begin}, true)
+				}
+				break
+			}
+
+			next := depth + 1
+			if c := ordered[next][depths[next]]; c.Rule != RuleUnknown && b.isParentOf(c) {
+				write(b, false)
+				depths[depth]++
+				depth, a, b = next, b, c
+				continue
+			}
+
+			write(b, true)
+			depths[depth]++
+			c, parent := ordered[depth][depths[depth]], true
+			for {
+				if c.Rule != RuleUnknown && a.isParentOf(c) {
+					b = c
+					continue depthFirstSearch
+				} else if parent && b.end != a.end {
+					write(token32{Rule: Rule_Suf, begin: b.end, end: a.end}, true)
+				}
+
+				depth--
+				if depth > 0 {
+					a, b, c = ordered[depth-1][depths[depth-1]-1], a, ordered[depth][depths[depth]]
+					parent = a.isParentOf(b)
+					continue
+				}
+
+				break depthFirstSearch
+			}
+		}
+
+		close(s)
+	}()
+	return s, ordered
+}
+
+func (t *tokens32) PrintSyntax() {
+	tokens, ordered := t.PreOrder()
+	max := -1
+	for token := range tokens {
+		if !token.leaf {
+			fmt.Printf("%v", token.begin)
+			for i, leaf, depths := 0, int(token.next), token.depths; i < leaf; i++ {
+				fmt.Printf(" \x1B[36m%v\x1B[m", Rul3s[ordered[i][depths[i]-1].Rule])
+			}
+			fmt.Printf(" \x1B[36m%v\x1B[m\n", Rul3s[token.Rule])
+		} else if token.begin == token.end {
+			fmt.Printf("%v", token.begin)
+			for i, leaf, depths := 0, int(token.next), token.depths; i < leaf; i++ {
+				fmt.Printf(" \x1B[31m%v\x1B[m", Rul3s[ordered[i][depths[i]-1].Rule])
+			}
+			fmt.Printf(" \x1B[31m%v\x1B[m\n", Rul3s[token.Rule])
+		} else {
+			for c, end := token.begin, token.end; c < end; c++ {
+				if i := int(c); max+1 < i {
+					for j := max; j < i; j++ {
+						fmt.Printf("skip %v %v\n", j, token.String())
+					}
+					max = i
+				} else if i := int(c); i <= max {
+					for j := i; j <= max; j++ {
+						fmt.Printf("dupe %v %v\n", j, token.String())
+					}
+				} else {
+					max = int(c)
+				}
+				fmt.Printf("%v", c)
+				for i, leaf, depths := 0, int(token.next), token.depths; i < leaf; i++ {
+					fmt.Printf(" \x1B[34m%v\x1B[m", Rul3s[ordered[i][depths[i]-1].Rule])
+				}
+				fmt.Printf(" \x1B[34m%v\x1B[m\n", Rul3s[token.Rule])
+			}
+			fmt.Printf("\n")
+		}
+	}
+}
+
+func (t *tokens32) PrintSyntaxTree(buffer string) {
+	tokens, _ := t.PreOrder()
+	for token := range tokens {
+		for c := 0; c < int(token.next); c++ {
+			fmt.Printf(" ")
+		}
+		fmt.Printf("\x1B[34m%v\x1B[m %v\n", Rul3s[token.Rule], strconv.Quote(buffer[token.begin:token.end]))
+	}
+}
+
+func (t *tokens32) Add(rule Rule, begin, end, depth, index int) {
+	t.tree[index] = token32{Rule: rule, begin: int32(begin), end: int32(end), next: int32(depth)}
+}
+
+func (t *tokens32) Tokens() <-chan token32 {
+	s := make(chan token32, 16)
+	go func() {
+		for _, v := range t.tree {
+			s <- v.GetToken32()
+		}
+		close(s)
+	}()
+	return s
+}
+
+func (t *tokens32) Error() []token32 {
+	ordered := t.Order()
+	length := len(ordered)
+	tokens, length := make([]token32, length), length-1
+	for i, _ := range tokens {
+		o := ordered[length-i]
+		if len(o) > 1 {
+			tokens[i] = o[len(o)-2].GetToken32()
+		}
+	}
+	return tokens
+}
+
+func (t *tokens16) Expand(index int) TokenTree {
+	tree := t.tree
+	if index < cap(tree) && index >= len(tree) {
+		t.tree = tree[:cap(tree)]
+	} else if index >= len(tree) {
+		expanded := make([]token32, 2*len(tree))
+		for i, v := range tree {
+			expanded[i] = v.GetToken32()
+		}
+		return &tokens32{tree: expanded}
+	}
+	return nil
+}
+
+func (t *tokens32) Expand(index int) TokenTree {
+	tree := t.tree
+	if index < cap(tree) && index >= len(tree) {
+		t.tree = tree[:cap(tree)]
+	} else if index >= len(tree) {
+		expanded := make([]token32, 2*len(tree))
+		copy(expanded, tree)
+		t.tree = expanded
+	}
+	return nil
+}
+
+type Labels struct {
+	Buffer string
+	buffer []rune
+	rules  [9]func() bool
+	match  func(rule Rule, offset int) (int, bool)
+	Parse  func(rule ...int) error
+	Reset  func()
+
+	TokenTree
+}
+
+type textPosition struct {
+	line, symbol int
+}
+
+type textPositionMap map[int]textPosition
+
+func translatePositions(buffer string, positions []int) textPositionMap {
+	length, translations, j, line, symbol := len(positions), make(textPositionMap, len(positions)), 0, 1, 0
+	sort.Ints(positions)
+
+search:
+	for i, c := range buffer[0:] {
+		if c == '\n' {
+			line, symbol = line+1, 0
+		} else {
+			symbol++
+		}
+		if i == positions[j] {
+			translations[positions[j]] = textPosition{line, symbol}
+			for j++; j < length; j++ {
+				if i != positions[j] {
+					continue search
+				}
+			}
+			break search
+		}
+	}
+
+	return translations
+}
+
+type parseError struct {
+	p *Labels
+}
+
+func (e *parseError) Error() string {
+	tokens, error := e.p.TokenTree.Error(), "\n"
+	positions, p := make([]int, 2*len(tokens)), 0
+	for _, token := range tokens {
+		positions[p], p = int(token.begin), p+1
+		positions[p], p = int(token.end), p+1
+	}
+	translations := translatePositions(e.p.Buffer, positions)
+	for _, token := range tokens {
+		begin, end := int(token.begin), int(token.end)
+		error += fmt.Sprintf("parse error near \x1B[34m%v\x1B[m (line %v symbol %v - line %v symbol %v):\n%v\n",
+			Rul3s[token.Rule],
+			translations[begin].line, translations[begin].symbol,
+			translations[end].line, translations[end].symbol,
+			/*strconv.Quote(*/ e.p.Buffer[begin:end] /*)*/)
+	}
+
+	return error
+}
+
+func (p *Labels) PrintSyntaxTree() {
+	p.TokenTree.PrintSyntaxTree(p.Buffer)
+}
+
+func (p *Labels) Highlighter() {
+	p.TokenTree.PrintSyntax()
+}
+
+/*
+The tokens hold rune offsets while the actions index the buffer in bytes, so this translates
+
+	a rune offset into a byte offset when the text isn't made of single byte runes.
+*/
+func byteOffset(text string) func(offset int32) int {
+	if len(text) == utf8.RuneCountInString(text) {
+		return func(offset int32) int { return int(offset) }
+	}
+	offsets := make([]int, 0, len(text)+1)
+	for i := range text {
+		offsets = append(offsets, i)
+	}
+	offsets = append(offsets, len(text))
+	return func(offset int32) int { return offsets[offset] }
+}
+
+func (p *Labels) Execute() {
+	buffer, begin, end, at := p.Buffer, 0, 0, byteOffset(p.Buffer)
+	/* the actions may only use labels */
+	_, _, _ = buffer, begin, end
+
+	/* labeled captures stay visible until the token of the rule containing them */
+	captures := make([]token32, 0, 8)
+	text := func(label Rule) string {
+		for i := len(captures) - 1; i >= 0; i-- {
+			if capture := captures[i]; capture.Rule == label {
+				return buffer[at(capture.begin):at(capture.end)]
+			}
+		}
+		return ""
+	}
+
+	for token := range p.TokenTree.Tokens() {
+		switch token.Rule {
+		case RulePegText:
+			begin, end = at(token.begin), at(token.end)
+		case RuleLabel_tag, RuleLabel_key, RuleLabel_value:
+			captures = append(captures, token)
+
+		case RuleAction0:
+			func(key, value string) {
+				fmt.Println(key, value)
+			}(text(RuleLabel_key), text(RuleLabel_value))
+
+		default:
+			for length := len(captures); length > 0 && captures[length-1].next > token.next; length-- {
+				captures = captures[:length-1]
+			}
+
+		}
+	}
+}
+
+/* ParseRule parses the buffer starting with the given rule, which must be used or public. */
+func (p *Labels) ParseRule(rule Rule) error {
+	if rule == RuleUnknown || int(rule) >= len(p.rules) || p.rules[rule] == nil {
+		name := fmt.Sprint(int(rule))
+		if int(rule) < len(Rul3s) {
+			name = Rul3s[rule]
+		}
+		return fmt.Errorf("rule %v isn't an entry point", name)
+	}
+	return p.Parse(int(rule))
+}
+
+/* ParseString parses the input starting with the given rule. */
+func (p *Labels) ParseString(rule Rule, input string) error {
+	p.Buffer = input
+	p.Init()
+	return p.ParseRule(rule)
+}
+
+/*
+Match matches the rule at the rune offset of the input, without requiring the rest of the
+
+	input to match, and returns the rune offset where the match ended. The rune buffer is only
+	rebuilt when the input changes, so a rule can be matched repeatedly along the same input.
+*/
+func (p *Labels) Match(rule Rule, input string, offset int) (end int, ok bool) {
+	if p.match == nil || input != p.Buffer {
+		p.Buffer = input
+		p.Init()
+	}
+	if rule == RuleUnknown || int(rule) >= len(p.rules) || p.rules[rule] == nil ||
+		offset < 0 || offset >= len(p.buffer) {
+		return 0, false
+	}
+	return p.match(rule, offset)
+}
+
+func (p *Labels) Init() {
+	p.buffer = []rune(p.Buffer)
+	if len(p.buffer) == 0 || p.buffer[len(p.buffer)-1] != END_SYMBOL {
+		p.buffer = append(p.buffer, END_SYMBOL)
+	}
+
+	var tree TokenTree = &tokens16{tree: make([]token16, math.MaxInt16)}
+	position, depth, tokenIndex, buffer, rules := 0, 0, 0, p.buffer, p.rules
+
+	p.Parse = func(rule ...int) error {
+		r := 1
+		if len(rule) > 0 {
+			r = rule[0]
+		}
+
+		matches := p.rules[r]()
+		p.TokenTree = tree
+		if matches {
+			p.TokenTree.trim(tokenIndex)
+
+			return nil
+		}
+		return &parseError{p}
+	}
+
+	p.Reset = func() {
+		position, tokenIndex, depth = 0, 0, 0
+
+	}
+
+	p.match = func(rule Rule, offset int) (int, bool) {
+		position, tokenIndex, depth = offset, 0, 0
+
+		matches := rules[rule]()
+		p.TokenTree = tree
+		if !matches {
+			return 0, false
+		}
+		p.TokenTree.trim(tokenIndex)
+		return position, true
+	}
+
+	add := func(rule Rule, begin int) {
+		if t := tree.Expand(tokenIndex); t != nil {
+			tree = t
+		}
+		tree.Add(rule, begin, position, depth, tokenIndex)
+
+		tokenIndex++
+	}
+
+	matchDot := func() bool {
+		if buffer[position] != END_SYMBOL {
+			position++
+			return true
+		}
+		return false
+	}
+
+	/*matchChar := func(c byte) bool {
+		if buffer[position] == c {
+			position++
+			return true
+		}
+		return false
+	}*/
+
+	matchCapture := func(capture [2]int) bool {
+		i := position
+		for _, c := range buffer[capture[0]:capture[1]] {
+			if buffer[i] != c {
+
+				return false
+			}
+			i++
+		}
+		position = i
+		return true
+	}
+
+	/*matchRange := func(lower byte, upper byte) bool {
+		if c := buffer[position]; c >= lower && c <= upper {
+			position++
+			return true
+		}
+		return false
+	}*/
+
+	rules = [...]func() bool{
+		nil,
+		/* 0 document <- <((heredoc / pair / ' ')* !.)> */
+		func() bool {
+			position0, tokenIndex0, depth0 := position, tokenIndex, depth
+			{
+				position1 := position
+				depth++
+			l2:
+				{
+					position3, tokenIndex3, depth3 := position, tokenIndex, depth
+					{
+						position4, tokenIndex4, depth4 := position, tokenIndex, depth
+						if !rules[Ruleheredoc]() {
+							goto l5
+						}
+						goto l4
+					l5:
+						position, tokenIndex, depth = position4, tokenIndex4, depth4
+						if !rules[Rulepair]() {
+							goto l6
+						}
+						goto l4
+					l6:
+						position, tokenIndex, depth = position4, tokenIndex4, depth4
+						if buffer[position] != rune(' ') {
+							goto l3
+						}
+						position++
+					}
+				l4:
+					goto l2
+				l3:
+					position, tokenIndex, depth = position3, tokenIndex3, depth3
+				}
+				{
+					position7, tokenIndex7, depth7 := position, tokenIndex, depth
+					if !matchDot() {
+						goto l7
+					}
+					goto l0
+				l7:
+					position, tokenIndex, depth = position7, tokenIndex7, depth7
+				}
+				depth--
+				add(Ruledocument, position1)
+			}
+			return true
+		l0:
+			position, tokenIndex, depth = position0, tokenIndex0, depth0
+			return false
+		},
+		/* 1 heredoc <- <('<' '<' tag:<[A-Z]+> '\n' (!('\n' $tag '\n') .)* '\n' $tag '\n')> */
+		func() bool {
+			position8, tokenIndex8, depth8 := position, tokenIndex, depth
+			{
+				var capture10 [2]int
+				position9 := position
+				depth++
+				if buffer[position] != rune('<') {
+					goto l8
+				}
+				position++
+				if buffer[position] != rune('<') {
+					goto l8
+				}
+				position++
+				{
+					position11 := position
+					depth++
+					{
+						position12 := position
+						depth++
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l8
+						}
+						position++
+					l13:
+						{
+							position14, tokenIndex14, depth14 := position, tokenIndex, depth
+							capture10_14 := capture10
+							if c := buffer[position]; c < rune('A') || c > rune('Z') {
+								goto l14
+							}
+							position++
+							goto l13
+						l14:
+							position, tokenIndex, depth = position14, tokenIndex14, depth14
+							capture10 = capture10_14
+						}
+						depth--
+						add(RulePegText, position12)
+					}
+					depth--
+					add(RuleLabel_tag, position11)
+					capture10 = [2]int{position11, position}
+				}
+				if buffer[position] != rune('\n') {
+					goto l8
+				}
+				position++
+			l15:
+				{
+					position16, tokenIndex16, depth16 := position, tokenIndex, depth
+					capture10_16 := capture10
+					{
+						position17, tokenIndex17, depth17 := position, tokenIndex, depth
+						capture10_17 := capture10
+						if buffer[position] != rune('\n') {
+							goto l17
+						}
+						position++
+						if !matchCapture(capture10) {
+							goto l17
+						}
+						if buffer[position] != rune('\n') {
+							goto l17
+						}
+						position++
+						goto l16
+					l17:
+						position, tokenIndex, depth = position17, tokenIndex17, depth17
+						capture10 = capture10_17
+					}
+					if !matchDot() {
+						goto l16
+					}
+					goto l15
+				l16:
+					position, tokenIndex, depth = position16, tokenIndex16, depth16
+					capture10 = capture10_16
+				}
+				if buffer[position] != rune('\n') {
+					goto l8
+				}
+				position++
+				if !matchCapture(capture10) {
+					goto l8
+				}
+				if buffer[position] != rune('\n') {
+					goto l8
+				}
+				position++
+				depth--
+				add(Ruleheredoc, position9)
+			}
+			return true
+		l8:
+			position, tokenIndex, depth = position8, tokenIndex8, depth8
+			return false
+		},
+		/* 2 pair <- <((key:<[a-z]+> '=' value:<[0-9]{1,3}> (',' [0-9]{2})* Action0) / (key:<[a-z]> ((key:<[a-z]+> '!') / ([a-z]* ':')) $key))> */
+		func() bool {
+			position18, tokenIndex18, depth18 := position, tokenIndex, depth
+			{
+				var capture20 [2]int
+				position19 := position
+				depth++
+				{
+					position21, tokenIndex21, depth21 := position, tokenIndex, depth
+					capture20_21 := capture20
+					{
+						position23 := position
+						depth++
+						{
+							position24 := position
+							depth++
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l22
+							}
+							position++
+						l25:
+							{
+								position26, tokenIndex26, depth26 := position, tokenIndex, depth
+								capture20_26 := capture20
+								if c := buffer[position]; c < rune('a') || c > rune('z') {
+									goto l26
+								}
+								position++
+								goto l25
+							l26:
+								position, tokenIndex, depth = position26, tokenIndex26, depth26
+								capture20 = capture20_26
+							}
+							depth--
+							add(RulePegText, position24)
+						}
+						depth--
+						add(RuleLabel_key, position23)
+						capture20 = [2]int{position23, position}
+					}
+					if buffer[position] != rune('=') {
+						goto l22
+					}
+					position++
+					{
+						position27 := position
+						depth++
+						{
+							position28 := position
+							depth++
+							{
+								count29 := 0
+							l29:
+								if count29 < 3 {
+									{
+										position30, tokenIndex30, depth30 := position, tokenIndex, depth
+										capture20_30 := capture20
+										if c := buffer[position]; c < rune('0') || c > rune('9') {
+											goto l30
+										}
+										position++
+										count29++
+										goto l29
+									l30:
+										position, tokenIndex, depth = position30, tokenIndex30, depth30
+										capture20 = capture20_30
+									}
+								}
+								if count29 < 1 {
+									goto l22
+								}
+							}
+							depth--
+							add(RulePegText, position28)
+						}
+						depth--
+						add(RuleLabel_value, position27)
+					}
+				l31:
+					{
+						position32, tokenIndex32, depth32 := position, tokenIndex, depth
+						capture20_32 := capture20
+						if buffer[position] != rune(',') {
+							goto l32
+						}
+						position++
+						{
+							count33 := 0
+						l33:
+							if count33 < 2 {
+								{
+									position34, tokenIndex34, depth34 := position, tokenIndex, depth
+									capture20_34 := capture20
+									if c := buffer[position]; c < rune('0') || c > rune('9') {
+										goto l34
+									}
+									position++
+									count33++
+									goto l33
+								l34:
+									position, tokenIndex, depth = position34, tokenIndex34, depth34
+									capture20 = capture20_34
+								}
+							}
+							if count33 < 2 {
+								goto l32
+							}
+						}
+						goto l31
+					l32:
+						position, tokenIndex, depth = position32, tokenIndex32, depth32
+						capture20 = capture20_32
+					}
+					if !rules[RuleAction0]() {
+						goto l22
+					}
+					goto l21
+				l22:
+					position, tokenIndex, depth = position21, tokenIndex21, depth21
+					capture20 = capture20_21
+					{
+						position35 := position
+						depth++
+						{
+							position36 := position
+							depth++
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l18
+							}
+							position++
+							depth--
+							add(RulePegText, position36)
+						}
+						depth--
+						add(RuleLabel_key, position35)
+						capture20 = [2]int{position35, position}
+					}
+					{
+						position37, tokenIndex37, depth37 := position, tokenIndex, depth
+						capture20_37 := capture20
+						{
+							position39 := position
+							depth++
+							{
+								position40 := position
+								depth++
+								if c := buffer[position]; c < rune('a') || c > rune('z') {
+									goto l38
+								}
+								position++
+							l41:
+								{
+									position42, tokenIndex42, depth42 := position, tokenIndex, depth
+									capture20_42 := capture20
+									if c := buffer[position]; c < rune('a') || c > rune('z') {
+										goto l42
+									}
+									position++
+									goto l41
+								l42:
+									position, tokenIndex, depth = position42, tokenIndex42, depth42
+									capture20 = capture20_42
+								}
+								depth--
+								add(RulePegText, position40)
+							}
+							depth--
+							add(RuleLabel_key, position39)
+							capture20 = [2]int{position39, position}
+						}
+						if buffer[position] != rune('!') {
+							goto l38
+						}
+						position++
+						goto l37
+					l38:
+						position, tokenIndex, depth = position37, tokenIndex37, depth37
+						capture20 = capture20_37
+					l43:
+						{
+							position44, tokenIndex44, depth44 := position, tokenIndex, depth
+							capture20_44 := capture20
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l44
+							}
+							position++
+							goto l43
+						l44:
+							position, tokenIndex, depth = position44, tokenIndex44, depth44
+							capture20 = capture20_44
+						}
+						if buffer[position] != rune(':') {
+							goto l18
+						}
+						position++
+					}
+				l37:
+					if !matchCapture(capture20) {
+						goto l18
+					}
+				}
+			l21:
+				depth--
+				add(Rulepair, position19)
+			}
+			return true
+		l18:
+			position, tokenIndex, depth = position18, tokenIndex18, depth18
+			return false
+		},
+		nil,
+		nil,
+		nil,
+		nil,
+		/* 8 Action0 <- <{ fmt.Println(key, value) }> */
+		func() bool {
+			{
+				add(RuleAction0, position)
+			}
+			return true
+		},
+	}
+	p.rules = rules
+}